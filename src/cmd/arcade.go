@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal"
+	"gti/src/internal/app"
+)
+
+var arcadeFlags app.ArcadeOptions
+
+var arcadeCmd = &cobra.Command{
+	Use:   "arcade [flags]",
+	Short: "falling-words arcade game",
+	Long: `usage: gti arcade [flags]
+
+Words fall from the top of the screen. Type a word to destroy it before it
+hits the ground. Every missed word costs a life, and each wave falls faster
+than the last. High scores are kept per language and every game is saved to
+your typing history.
+
+flags:
+  -l, --language <lang>  language for the falling words (default: configured language)
+  --lives <num>          number of lives (default: 3)
+
+CONTROLS:
+  Type          Aim at the lowest word matching your input
+  Backspace     Delete the last letter
+  Esc           Clear your input
+  Enter         Start / play again
+  Ctrl+C        Quit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if arcadeFlags.Language != "" && !internal.IsLanguageSupported(arcadeFlags.Language) {
+			return fmt.Errorf("language '%s' is not supported", arcadeFlags.Language)
+		}
+		return app.StartArcade(arcadeFlags)
+	},
+}
+
+func init() {
+	arcadeCmd.Flags().StringVarP(&arcadeFlags.Language, "language", "l", "", "language for the falling words")
+	arcadeCmd.Flags().IntVar(&arcadeFlags.Lives, "lives", 3, "number of lives")
+}
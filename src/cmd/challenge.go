@@ -1,11 +1,21 @@
 package cmd
 
 import (
+	"fmt"
+
 	"gti/src/internal/app"
+	"gti/src/internal/challenge"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	challengePack      string
+	challengeListPacks bool
+	challengeAdaptive  bool
+	challengeSurvival  bool
+)
+
 var challengeCmd = &cobra.Command{
 	Use:   "challenge",
 	Short: "Start progressive challenge mode with levels",
@@ -13,9 +23,18 @@ var challengeCmd = &cobra.Command{
 Complete increasingly difficult typing challenges to unlock achievements.
 
 EXAMPLES:
-  gti challenge    # Start from current level
+  gti challenge                 # Open the level map at your current level
+  gti challenge --pack team     # Play the level pack challenges/team.toml
+  gti challenge --list-packs    # List installed level packs
+  gti challenge --adaptive      # Endless levels tuned to your skill
+  gti challenge --survival      # Beat the clock for as long as you can
 
 CONTROLS: Same as other modes
+  Level map:
+    Up/Down       Select a level (unlocked levels only)
+    Enter         Play the selected level
+    Q/Esc         Quit
+
   During challenge:
     Type normally to meet requirements
     Tab/Enter    Submit when requirements met
@@ -23,13 +42,103 @@ CONTROLS: Same as other modes
 
   Results screen:
     Enter         Continue to next level
-    Ctrl+R        Retry current level
+    M             Back to the level map
+    R             Retry a failed level
     Ctrl+C        Quit to main menu
 
+LEVEL PACKS:
+  Level packs are TOML files in the challenges directory of the config
+  directory. Each [[levels]] entry accepts name, time_seconds, min_accuracy,
+  max_mistakes, min_chars, min_words, min_wpm, min_net_wpm, is_boss,
+  modifiers, language and text_source
+  (a text file to draw words from, relative to the challenges directory).
+
+    name = "Team warmup"
+
+    [[levels]]
+    name = "Home row"
+    time_seconds = 30
+    min_accuracy = 95.0
+    max_mistakes = 10
+    min_chars = 60
+    min_words = 12
+    min_wpm = 20.0
+    language = "english"
+
+    [[levels.hidden_bosses]]
+    name = "Ambush"
+    trigger_chunk = 2
+    words = 12
+    time_seconds = 15
+    modifiers = ["reversed", "no_backspace"]
+
+  A hidden boss interrupts the level when it reaches trigger_chunk. The
+  level timer pauses while the boss runs on its own time limit.
+
+ADAPTIVE MODE:
+  Levels are generated from your recent history. Each pass raises the
+  difficulty rating and each fail lowers it, settling where you pass about
+  70% of levels. Adaptive runs do not change level map progress.
+
+SURVIVAL MODE:
+  The clock starts at 10 seconds. Every finished word adds time, long
+  combos of exact words add more, and each mistake costs 2 seconds. Words
+  get longer every 15 words. The top 10 runs are kept as high scores.
+
+BOSS MODIFIERS:
+  Boss levels and hidden bosses accept a list of modifiers, which combine:
+    memory          Text fades after a few seconds
+    reversed        Every word is spelled backwards
+    hidden_errors   Mistakes are not highlighted
+    no_backspace    Backspace is disabled
+    random_caps     Letters are randomly capitalised
+    shrinking_time  The clock speeds up as time passes
+
 PROGRESS:
   Challenge progress is saved automatically
+  Each level pack keeps its own progress
+  Best WPM, accuracy and a 1-3 star rating are kept per level
   Failed attempts don't reset progress`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return app.StartChallengeGame()
+		if challengeListPacks {
+			return listChallengePacks()
+		}
+		if challengeSurvival {
+			if challengePack != "" || challengeAdaptive {
+				return fmt.Errorf("--survival cannot be combined with --pack or --adaptive")
+			}
+			return app.StartSurvival()
+		}
+		if challengeAdaptive {
+			if challengePack != "" {
+				return fmt.Errorf("--adaptive cannot be combined with --pack")
+			}
+			return app.StartAdaptiveChallenge()
+		}
+		return app.StartChallengeGame(challengePack)
 	},
 }
+
+func listChallengePacks() error {
+	packs, err := challenge.LoadPacks()
+	if len(packs) == 0 && err == nil {
+		fmt.Printf("No level packs found in %s\n", challenge.PacksDir())
+		return nil
+	}
+
+	fmt.Println("Available level packs:")
+	for _, pack := range packs {
+		fmt.Printf("  %-16s %-24s %d levels\n", pack.ID, pack.Name, len(pack.Levels))
+		if pack.Description != "" {
+			fmt.Printf("  %-16s %s\n", "", pack.Description)
+		}
+	}
+	return err
+}
+
+func init() {
+	challengeCmd.Flags().StringVar(&challengePack, "pack", "", "play a level pack from the challenges directory")
+	challengeCmd.Flags().BoolVar(&challengeListPacks, "list-packs", false, "list installed level packs")
+	challengeCmd.Flags().BoolVar(&challengeAdaptive, "adaptive", false, "generate levels that adapt to your skill")
+	challengeCmd.Flags().BoolVar(&challengeSurvival, "survival", false, "play endless survival mode")
+}
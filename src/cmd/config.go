@@ -27,6 +27,7 @@ flags:
 			printTimedConfig(cfg.Timed)
 			printThemeConfig(cfg.Theme)
 			printHistoryConfig(cfg.History)
+			printGoalsConfig(cfg.Goals)
 		} else if resetFlag {
 			fmt.Println("Resetting config to defaults...")
 			if err := config.GenerateConfig(); err != nil {
@@ -54,8 +55,20 @@ func printThemeConfig(theme config.ThemeConfig) {
 
 func printHistoryConfig(history config.HistoryConfig) {
 	fmt.Println("History:")
-	fmt.Printf("  Enabled: %t\n", history.Enabled)
-	fmt.Printf("  File:    %s\n", history.File)
+	fmt.Printf("  Enabled:  %t\n", history.Enabled)
+	fmt.Printf("  Backend:  %s\n", history.Backend)
+	fmt.Printf("  File:     %s\n", history.File)
+	fmt.Printf("  Database: %s\n", history.Database)
+	fmt.Println()
+}
+
+func printGoalsConfig(goals config.GoalsConfig) {
+	fmt.Println("Goals:")
+	fmt.Printf("  Minutes per day:  %d\n", goals.MinutesPerDay)
+	fmt.Printf("  Sessions per day: %d\n", goals.SessionsPerDay)
+	fmt.Printf("  Minutes per week: %d\n", goals.MinutesPerWeek)
+	fmt.Printf("  Target WPM:       %.0f\n", goals.TargetWPM)
+	fmt.Printf("  Target date:      %s\n", goals.TargetDate)
 	fmt.Println()
 }
 
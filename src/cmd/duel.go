@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"gti/src/internal/app"
+)
+
+var duelFlags app.DuelOptions
+
+var duelCmd = &cobra.Command{
+	Use:   "duel [flags]",
+	Short: "two-player hot-seat duel in one terminal",
+	Long: `usage: gti duel [flags]
+
+Two players take turns typing the same seeded text. After each round a
+comparison screen shows WPM, accuracy and mistakes side by side.
+
+flags:
+  --p1 <name>         name of the first player (default: Player 1)
+  --p2 <name>         name of the second player (default: Player 2)
+  -r, --rounds <num>  play best-of-N rounds (default: 1)
+  -w, --words <num>   number of words per round (default: 25)
+  --seed <num>        seed for the round texts (default: random)
+
+CONTROLS:
+  Enter         Start turn / continue
+  Esc           Restart the current turn (once per turn)
+  R             Rematch from the final screen
+  Ctrl+C        Quit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.StartDuel(duelFlags)
+	},
+}
+
+func init() {
+	duelCmd.Flags().StringVar(&duelFlags.Player1, "p1", "Player 1", "name of the first player")
+	duelCmd.Flags().StringVar(&duelFlags.Player2, "p2", "Player 2", "name of the second player")
+	duelCmd.Flags().IntVarP(&duelFlags.Rounds, "rounds", "r", 1, "play best-of-N rounds")
+	duelCmd.Flags().IntVarP(&duelFlags.Words, "words", "w", 25, "number of words per round")
+	duelCmd.Flags().Int64Var(&duelFlags.Seed, "seed", 0, "seed for the round texts (0 picks a random seed)")
+}
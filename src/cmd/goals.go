@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+)
+
+type goalsCmdFlags struct {
+	minutesPerDay  int
+	sessionsPerDay int
+	minutesPerWeek int
+	targetWPM      float64
+	by             string
+	json           bool
+}
+
+var goalsFlags goalsCmdFlags
+
+var goalsCmd = &cobra.Command{
+	Use:   "goals [command]",
+	Short: "set and review practice goals",
+	Long: `usage: gti goals [command]
+
+Goals turn practice into a daily habit. Progress towards them shows in the
+status bar while typing and in the statistics view.
+
+commands:
+  (none)    show today's and this week's progress
+  set       set one or more goals; 0 turns a goal off
+  clear     turn every goal off
+
+examples:
+  gti goals set --minutes-per-day 20 --sessions-per-day 3
+  gti goals set --minutes-per-week 120
+  gti goals set --target-wpm 80 --by 2025-12-31
+  gti goals --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.GetConfig()
+		progress, err := session.LoadGoalProgress(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to load history: %w", err)
+		}
+
+		if goalsFlags.json {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(map[string]interface{}{
+				"goals":    cfg.Goals,
+				"progress": progress.Items(),
+			})
+		}
+
+		if !cfg.Goals.IsSet() {
+			fmt.Println("No goals set. Set one with: gti goals set --minutes-per-day 20")
+			return nil
+		}
+		printGoalProgress(progress)
+		return nil
+	},
+}
+
+var goalsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "set practice goals",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		if flags.NFlag() == 0 {
+			return fmt.Errorf("nothing to set: pass --minutes-per-day, --sessions-per-day, --minutes-per-week or --target-wpm")
+		}
+		if goalsFlags.minutesPerDay < 0 || goalsFlags.sessionsPerDay < 0 || goalsFlags.minutesPerWeek < 0 || goalsFlags.targetWPM < 0 {
+			return fmt.Errorf("goals cannot be negative")
+		}
+
+		cfg := config.GetConfig()
+		goals := cfg.Goals
+		if flags.Changed("minutes-per-day") {
+			goals.MinutesPerDay = goalsFlags.minutesPerDay
+		}
+		if flags.Changed("sessions-per-day") {
+			goals.SessionsPerDay = goalsFlags.sessionsPerDay
+		}
+		if flags.Changed("minutes-per-week") {
+			goals.MinutesPerWeek = goalsFlags.minutesPerWeek
+		}
+		if flags.Changed("target-wpm") {
+			goals.TargetWPM = goalsFlags.targetWPM
+			if goals.TargetWPM == 0 {
+				goals.TargetDate = ""
+			}
+		}
+		if flags.Changed("by") {
+			if goalsFlags.by != "" {
+				if _, err := time.Parse("2006-01-02", goalsFlags.by); err != nil {
+					return fmt.Errorf("invalid date '%s': use YYYY-MM-DD", goalsFlags.by)
+				}
+			}
+			if goals.TargetWPM == 0 && goalsFlags.by != "" {
+				return fmt.Errorf("--by needs a WPM goal: pass --target-wpm as well")
+			}
+			goals.TargetDate = goalsFlags.by
+		}
+
+		cfg.Goals = goals
+		if err := config.SaveConfig(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Println("Goals saved.")
+
+		progress, err := session.LoadGoalProgress(cfg)
+		if err == nil && goals.IsSet() {
+			fmt.Println()
+			printGoalProgress(progress)
+		}
+		return nil
+	},
+}
+
+var goalsClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "turn every goal off",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.GetConfig()
+		cfg.Goals = config.GoalsConfig{}
+		if err := config.SaveConfig(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Println("Goals cleared.")
+		return nil
+	},
+}
+
+func printGoalProgress(progress session.GoalProgress) {
+	const barWidth = 20
+	for _, item := range progress.Items() {
+		filled := int(item.Fraction() * barWidth)
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+		mark := " "
+		if item.Done {
+			mark = "✓"
+		}
+
+		value := fmt.Sprintf("%.0f/%.0f %s", item.Current, item.Target, item.Unit)
+		if item.Unit == "wpm" {
+			value = fmt.Sprintf("%.1f/%.0f %s", item.Current, item.Target, item.Unit)
+		}
+		line := fmt.Sprintf("%s %-18s %s %s", mark, item.Name, bar, value)
+		if item.Note != "" {
+			line += " (" + item.Note + ")"
+		}
+		fmt.Println(line)
+	}
+}
+
+func init() {
+	goalsCmd.Flags().BoolVar(&goalsFlags.json, "json", false, "print goals and progress as JSON")
+
+	goalsSetCmd.Flags().IntVar(&goalsFlags.minutesPerDay, "minutes-per-day", 0, "minutes to practise each day")
+	goalsSetCmd.Flags().IntVar(&goalsFlags.sessionsPerDay, "sessions-per-day", 0, "sessions to finish each day")
+	goalsSetCmd.Flags().IntVar(&goalsFlags.minutesPerWeek, "minutes-per-week", 0, "minutes to practise each week")
+	goalsSetCmd.Flags().Float64Var(&goalsFlags.targetWPM, "target-wpm", 0, "average WPM to reach over recent sessions")
+	goalsSetCmd.Flags().StringVar(&goalsFlags.by, "by", "", "date to reach the WPM goal by (YYYY-MM-DD)")
+
+	goalsCmd.AddCommand(goalsSetCmd)
+	goalsCmd.AddCommand(goalsClearCmd)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+)
+
+const unreadablePreviewLimit = 10
+
+var historyMigrateDryRun bool
+
+var historyConvertFlags struct {
+	to  string
+	use bool
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history <command>",
+	Short: "manage typing history",
+	Long: `usage: gti history <command>
+
+commands:
+  list       list sessions, newest first
+  show       show every field of one session
+  delete     delete sessions from history
+  tail       show the latest sessions, optionally following new ones
+  import     import results from monkeytype, keybr or a CSV file
+  migrate    upgrade every record to the current history format
+  convert    copy history into another storage backend
+
+Run 'gti history <command> --help' for filters and flags.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var historyMigrateCmd = &cobra.Command{
+	Use:   "migrate [--dry-run]",
+	Short: "upgrade every record to the current history format",
+	Long: `usage: gti history migrate [--dry-run]
+
+Older records are upgraded every time history is read. This command does it
+once and for all by rewriting the history file. The original file is kept as
+a timestamped .bak next to it, and lines that cannot be read are moved to a
+.rejected file so nothing is lost.
+
+flags:
+  --dry-run    report what would change without writing anything`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.GetConfig()
+		store, err := session.OpenStore(cfg.History)
+		if err != nil {
+			return err
+		}
+		path := store.Path()
+
+		result, err := session.MigrateHistory(store, historyMigrateDryRun)
+		if os.IsNotExist(err) {
+			fmt.Printf("No history file at %s.\n", path)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate history: %w", err)
+		}
+
+		report := result.Report
+		fmt.Printf("%s: %s\n", path, report.Summary())
+		printUnreadableLines(report)
+
+		switch {
+		case historyMigrateDryRun:
+			fmt.Println("Dry run: nothing was written.")
+		case !result.Rewritten:
+			fmt.Printf("History is already at schema version %d.\n", session.CurrentSchemaVersion)
+		default:
+			fmt.Printf("Rewrote history at schema version %d.\n", session.CurrentSchemaVersion)
+			fmt.Printf("Backup: %s\n", result.BackupPath)
+			if result.RejectedPath != "" {
+				fmt.Printf("Unreadable lines saved to: %s\n", result.RejectedPath)
+			}
+		}
+		return nil
+	},
+}
+
+var historyConvertCmd = &cobra.Command{
+	Use:   "convert --to <backend> [--use]",
+	Short: "copy history into another storage backend",
+	Long: `usage: gti history convert --to <backend> [--use]
+
+Copies every session from the configured backend into another one. Sessions
+already present in the destination are skipped, so converting twice is safe.
+The source is left untouched.
+
+backends:
+  jsonl    one JSON record per line in history.file (default)
+  bolt     embedded database in history.database, indexed by time, mode
+           and language
+
+flags:
+  --to <backend>  backend to copy into (required)
+  --use           switch the config to the new backend afterwards`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.GetConfig()
+		if historyConvertFlags.to == "" {
+			return fmt.Errorf("--to is required")
+		}
+		if historyConvertFlags.to == cfg.History.Backend {
+			return fmt.Errorf("history already uses the %s backend", cfg.History.Backend)
+		}
+
+		from, err := session.OpenStore(cfg.History)
+		if err != nil {
+			return err
+		}
+		to, err := session.NewStore(historyConvertFlags.to, cfg.History)
+		if err != nil {
+			return err
+		}
+
+		added, err := session.ConvertHistory(from, to)
+		if err != nil {
+			return fmt.Errorf("failed to convert history: %w", err)
+		}
+		fmt.Printf("Copied %d sessions from %s to %s.\n", added, from.Path(), to.Path())
+
+		if !historyConvertFlags.use {
+			fmt.Printf("Set backend = \"%s\" under [history] in your config, or rerun with --use, to switch.\n", historyConvertFlags.to)
+			return nil
+		}
+		cfg.History.Backend = historyConvertFlags.to
+		if err := config.SaveConfig(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("History now uses the %s backend.\n", historyConvertFlags.to)
+		return nil
+	},
+}
+
+func printUnreadableLines(report *session.LoadReport) {
+	for i, line := range report.Unreadable {
+		if i == unreadablePreviewLimit {
+			fmt.Printf("  ... and %d more\n", len(report.Unreadable)-i)
+			break
+		}
+		text := line.Text
+		if len(text) > 60 {
+			text = text[:57] + "..."
+		}
+		fmt.Printf("  line %d: %s (%s)\n", line.Line, text, line.Error)
+	}
+}
+
+func init() {
+	historyMigrateCmd.Flags().BoolVar(&historyMigrateDryRun, "dry-run", false, "report what would change without writing anything")
+	historyConvertCmd.Flags().StringVar(&historyConvertFlags.to, "to", "", "backend to copy into: jsonl or bolt")
+	historyConvertCmd.Flags().BoolVar(&historyConvertFlags.use, "use", false, "switch the config to the new backend afterwards")
+	historyCmd.AddCommand(historyMigrateCmd)
+	historyCmd.AddCommand(historyConvertCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal/config"
+	"gti/src/internal/importer"
+	"gti/src/internal/session"
+)
+
+var historyImportFlags struct {
+	from   string
+	dryRun bool
+}
+
+var historyImportCmd = &cobra.Command{
+	Use:   "import --from <source> <file>",
+	Short: "import results from another typing trainer",
+	Long: `usage: gti history import --from <source> <file> [--dry-run]
+
+Converts exported results from another trainer into gti sessions, so your
+progress carries over. Imported sessions are tagged with their source, and
+importing the same file again only adds results that are new.
+
+sources:
+  monkeytype  CSV from Account > Export CSV
+  keybr       JSON from Profile > Download data
+  csv         any CSV with a header row. Required columns: timestamp (or
+              date), wpm and duration in seconds (or duration_ms).
+              Optional: raw_wpm, net_wpm, accuracy, chars, mistakes, mode,
+              language, id
+
+Raw speed becomes WPM and net speed becomes Net WPM, the way gti measures
+its own sessions.
+
+flags:
+  --from <source>  format of the file (required)
+  --dry-run        show what would be imported without saving`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if historyImportFlags.from == "" {
+			return fmt.Errorf("--from is required. Valid options: monkeytype, keybr, csv")
+		}
+
+		file, err := os.Open(config.ExpandPath(args[0]))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		result, err := importer.Parse(historyImportFlags.from, file)
+		if err != nil {
+			return err
+		}
+		for _, skipped := range result.Skipped {
+			fmt.Printf("  skipped %s\n", skipped)
+		}
+
+		if historyImportFlags.dryRun {
+			fmt.Printf("Found %d sessions in %s (dry run, nothing saved).\n", len(result.Records), args[0])
+			return nil
+		}
+		if len(result.Records) == 0 {
+			fmt.Println("No sessions found to import.")
+			return nil
+		}
+
+		store, err := session.OpenStore(config.GetConfig().History)
+		if err != nil {
+			return err
+		}
+		added, duplicates, err := session.ImportRecords(store, result.Records)
+		if err != nil {
+			return fmt.Errorf("failed to import sessions: %w", err)
+		}
+		fmt.Printf("Imported %d sessions from %s", added, historyImportFlags.from)
+		if duplicates > 0 {
+			fmt.Printf(" (%d already imported)", duplicates)
+		}
+		fmt.Println(".")
+		return nil
+	},
+}
+
+func init() {
+	historyImportCmd.Flags().StringVar(&historyImportFlags.from, "from", "", "format of the file: monkeytype, keybr or csv")
+	historyImportCmd.Flags().BoolVar(&historyImportFlags.dryRun, "dry-run", false, "show what would be imported without saving")
+	historyCmd.AddCommand(historyImportCmd)
+}
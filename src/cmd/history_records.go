@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal/analytics"
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+)
+
+type historyFilterFlags struct {
+	mode     string
+	since    string
+	until    string
+	minWPM   float64
+	language string
+	json     bool
+}
+
+var historyFilters historyFilterFlags
+
+var historyListLimit int
+var historyTailLimit int
+var historyDeleteYes bool
+var historyDeleteMatching bool
+var historyTailFollow bool
+
+const historyFilterHelp = `filters:
+  --mode <mode>        only sessions of this mode, e.g. practice or quote
+  --since <time>       sessions at or after a date (2024-05-01), a time
+                       (2024-05-01T18:00:00Z) or a duration ago (7d, 12h, 2w)
+  --until <time>       sessions at or before a date, time or duration ago
+  --min-wpm <wpm>      sessions of at least this speed
+  --language <lang>    sessions typed in this language`
+
+var historyListCmd = &cobra.Command{
+	Use:   "list [filters]",
+	Short: "list sessions, newest first",
+	Long: `usage: gti history list [filters] [--limit <n>] [--json]
+
+` + historyFilterHelp + `
+
+flags:
+  -n, --limit <n>      show at most n sessions (default: 20, 0 for all)
+  --json               print records as JSON`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query, err := historyQuery()
+		if err != nil {
+			return err
+		}
+		query.Limit = historyListLimit
+
+		store, err := session.OpenStore(config.GetConfig().History)
+		if err != nil {
+			return err
+		}
+		records, err := store.Query(query)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+
+		if historyFilters.json {
+			return printHistoryJSON(records)
+		}
+		if len(records) == 0 {
+			fmt.Println("No sessions found.")
+			return nil
+		}
+		printHistoryTable(records)
+		return nil
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "show every field of one session",
+	Long: `usage: gti history show <id> [--json]
+
+The id is the first column of 'gti history list'. Any unique prefix of at
+least four characters works.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		records, err := loadAllHistory()
+		if err != nil {
+			return err
+		}
+		record, err := session.FindRecord(records, args[0])
+		if err != nil {
+			return err
+		}
+
+		if historyFilters.json {
+			return printHistoryJSON(record)
+		}
+		printHistoryRecord(record)
+		return nil
+	},
+}
+
+var historyDeleteCmd = &cobra.Command{
+	Use:   "delete <id>... | --matching [filters]",
+	Short: "delete sessions from history",
+	Long: `usage: gti history delete <id>... [-y]
+       gti history delete --matching [filters] [-y]
+
+Removes sessions that skew your statistics, such as a test you walked away
+from. History is rewritten in one step, so an interrupted delete never
+leaves a damaged file.
+
+` + historyFilterHelp + `
+
+flags:
+  --matching           delete every session matching the filters
+  -y, --yes            delete without asking for confirmation`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := session.OpenStore(config.GetConfig().History)
+		if err != nil {
+			return err
+		}
+
+		var remove []*session.SessionRecord
+		if historyDeleteMatching {
+			if len(args) > 0 {
+				return fmt.Errorf("use either session ids or --matching, not both")
+			}
+			query, err := historyQuery()
+			if err != nil {
+				return err
+			}
+			if query == (session.Query{}) {
+				return fmt.Errorf("--matching needs at least one filter")
+			}
+			if remove, err = store.Query(query); err != nil {
+				return fmt.Errorf("failed to read history: %w", err)
+			}
+		} else {
+			if len(args) == 0 {
+				return fmt.Errorf("give the ids of the sessions to delete, or --matching with filters")
+			}
+			records, _, err := store.Load()
+			if err != nil {
+				return fmt.Errorf("failed to read history: %w", err)
+			}
+			for _, id := range args {
+				record, err := session.FindRecord(records, id)
+				if err != nil {
+					return err
+				}
+				remove = append(remove, record)
+			}
+		}
+
+		if len(remove) == 0 {
+			fmt.Println("No sessions match.")
+			return nil
+		}
+
+		printHistoryTable(remove)
+		if !historyDeleteYes {
+			fmt.Printf("\nDelete %d session(s)? (y/N) ", len(remove))
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		removed, err := session.DeleteRecords(store, remove)
+		if err != nil {
+			return fmt.Errorf("failed to delete sessions: %w", err)
+		}
+		fmt.Printf("Deleted %d session(s).\n", removed)
+		return nil
+	},
+}
+
+var historyTailCmd = &cobra.Command{
+	Use:   "tail [filters]",
+	Short: "show the latest sessions, oldest first",
+	Long: `usage: gti history tail [filters] [-n <n>] [-f] [--json]
+
+` + historyFilterHelp + `
+
+flags:
+  -n, --limit <n>      show the last n sessions (default: 10)
+  -f, --follow         keep watching and print sessions as they finish
+  --json               print one JSON record per line`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query, err := historyQuery()
+		if err != nil {
+			return err
+		}
+		query.Limit = historyTailLimit
+
+		store, err := session.OpenStore(config.GetConfig().History)
+		if err != nil {
+			return err
+		}
+		records, err := store.Query(query)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+		if !historyFilters.json {
+			printHistoryHeader()
+		}
+		printHistoryLines(records)
+
+		if !historyTailFollow {
+			return nil
+		}
+
+		last := time.Now()
+		if len(records) > 0 {
+			last = records[len(records)-1].Timestamp
+		}
+		query.Limit = 0
+		for {
+			time.Sleep(time.Second)
+			query.Since = last.Add(time.Nanosecond)
+			fresh, err := store.Query(query)
+			if err != nil {
+				return fmt.Errorf("failed to read history: %w", err)
+			}
+			for i := len(fresh) - 1; i >= 0; i-- {
+				printHistoryLines(fresh[i : i+1])
+				last = fresh[i].Timestamp
+			}
+		}
+	},
+}
+
+func historyQuery() (session.Query, error) {
+	query := session.Query{
+		Mode:     historyFilters.mode,
+		Language: historyFilters.language,
+		MinWPM:   historyFilters.minWPM,
+	}
+
+	var err error
+	if historyFilters.since != "" {
+		if query.Since, err = analytics.ParseTime(historyFilters.since, false); err != nil {
+			return query, err
+		}
+	}
+	if historyFilters.until != "" {
+		if query.Until, err = analytics.ParseTime(historyFilters.until, true); err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
+
+func loadAllHistory() ([]*session.SessionRecord, error) {
+	store, err := session.OpenStore(config.GetConfig().History)
+	if err != nil {
+		return nil, err
+	}
+	records, _, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return records, nil
+}
+
+func printHistoryJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func printHistoryTable(records []*session.SessionRecord) {
+	printHistoryHeader()
+	printHistoryLines(records)
+}
+
+func printHistoryHeader() {
+	fmt.Printf("%-8s  %-16s  %-14s  %-10s  %6s  %6s  %7s  %6s\n", "ID", "DATE", "MODE", "LANGUAGE", "WPM", "ACC", "TIME", "CHARS")
+}
+
+// printHistoryLines prints table rows, or one JSON object per line when
+// --json is set so that tail -f output can be piped.
+func printHistoryLines(records []*session.SessionRecord) {
+	for _, r := range records {
+		if historyFilters.json {
+			data, _ := json.Marshal(r)
+			fmt.Println(string(data))
+			continue
+		}
+		language := r.Language
+		if language == "" {
+			language = "-"
+		}
+		fmt.Printf("%-8s  %-16s  %-14s  %-10s  %6.1f  %5.1f%%  %6.1fs  %6d\n",
+			r.ID(),
+			r.Timestamp.Local().Format("2006-01-02 15:04"),
+			truncateField(r.Mode, 14),
+			truncateField(language, 10),
+			r.WPM,
+			r.Accuracy,
+			float64(r.DurationMs)/1000,
+			r.TextLength,
+		)
+	}
+}
+
+func truncateField(value string, width int) string {
+	if len(value) > width {
+		return value[:width-1] + "…"
+	}
+	return value
+}
+
+func printHistoryRecord(r *session.SessionRecord) {
+	row := func(label string, value interface{}) {
+		fmt.Printf("%-20s %v\n", label+":", value)
+	}
+	row("ID", r.ID())
+	row("Date", r.Timestamp.Local().Format("2006-01-02 15:04:05"))
+	row("Mode", r.Mode)
+	if r.Tier != "" {
+		row("Tier", r.Tier)
+	}
+	if r.Player != "" {
+		row("Player", r.Player)
+	}
+	if r.Language != "" {
+		row("Language", r.Language)
+	}
+	if r.TextSource != "" {
+		row("Text source", r.TextSource)
+	}
+	if r.QuoteAuthor != "" {
+		row("Quote author", r.QuoteAuthor)
+	}
+	row("Duration", fmt.Sprintf("%.1fs", float64(r.DurationMs)/1000))
+	if r.DurationTargetMs > 0 {
+		row("Time limit", fmt.Sprintf("%.0fs", float64(r.DurationTargetMs)/1000))
+	}
+	row("Characters", r.TextLength)
+	row("WPM", fmt.Sprintf("%.1f", r.WPM))
+	row("Net WPM", fmt.Sprintf("%.1f", r.NetWPM))
+	if r.AdjustedWPM > 0 {
+		row("Adjusted WPM", fmt.Sprintf("%.1f", r.AdjustedWPM))
+	}
+	row("CPM", fmt.Sprintf("%.1f", r.CPM))
+	row("Accuracy", fmt.Sprintf("%.1f%%", r.Accuracy))
+	row("Mistakes", r.Mistakes)
+	row("Corrected errors", r.CorrectedErrors)
+	row("Uncorrected errors", r.UncorrectedErrors)
+	row("Backspaces", r.BackspaceCount)
+	if r.Typos != nil {
+		row("Typos", fmt.Sprintf("%d substituted, %d inserted, %d omitted, %d transposed",
+			r.Typos.Substitutions, r.Typos.Insertions, r.Typos.Omissions, r.Typos.Transpositions))
+		if len(r.Typos.Confusions) > 0 {
+			row("Confused keys", session.FormatConfusions(r.Typos.Confusions, 5))
+		}
+	}
+	row("Counts in stats", session.IsValidRecord(r))
+	row("Schema version", r.SchemaVersion)
+}
+
+func addHistoryFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&historyFilters.mode, "mode", "", "only sessions of this mode")
+	cmd.Flags().StringVar(&historyFilters.since, "since", "", "sessions at or after this date, time or duration ago")
+	cmd.Flags().StringVar(&historyFilters.until, "until", "", "sessions at or before this date, time or duration ago")
+	cmd.Flags().Float64Var(&historyFilters.minWPM, "min-wpm", 0, "sessions of at least this WPM")
+	cmd.Flags().StringVar(&historyFilters.language, "language", "", "sessions typed in this language")
+}
+
+func init() {
+	addHistoryFilterFlags(historyListCmd)
+	historyListCmd.Flags().IntVarP(&historyListLimit, "limit", "n", 20, "show at most n sessions (0 for all)")
+	historyListCmd.Flags().BoolVar(&historyFilters.json, "json", false, "print records as JSON")
+
+	historyShowCmd.Flags().BoolVar(&historyFilters.json, "json", false, "print the record as JSON")
+
+	addHistoryFilterFlags(historyDeleteCmd)
+	historyDeleteCmd.Flags().BoolVar(&historyDeleteMatching, "matching", false, "delete every session matching the filters")
+	historyDeleteCmd.Flags().BoolVarP(&historyDeleteYes, "yes", "y", false, "delete without asking for confirmation")
+
+	addHistoryFilterFlags(historyTailCmd)
+	historyTailCmd.Flags().IntVarP(&historyTailLimit, "limit", "n", 10, "show the last n sessions")
+	historyTailCmd.Flags().BoolVarP(&historyTailFollow, "follow", "f", false, "keep watching for new sessions")
+	historyTailCmd.Flags().BoolVar(&historyFilters.json, "json", false, "print one JSON record per line")
+
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyDeleteCmd)
+	historyCmd.AddCommand(historyTailCmd)
+}
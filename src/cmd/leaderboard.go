@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"gti/src/internal/config"
+	"gti/src/internal/leaderboard"
+	"gti/src/internal/tui"
+)
+
+type leaderboardCmdFlags struct {
+	dir    string
+	mode   string
+	window string
+	json   bool
+}
+
+var lbFlags leaderboardCmdFlags
+
+var leaderboardCmd = &cobra.Command{
+	Use:   "leaderboard --dir <folder> [flags]",
+	Short: "rank a team from a shared folder of histories",
+	Long: `usage: gti leaderboard --dir <folder> [flags]
+
+Reads everyone's history files from a shared folder, such as a network share
+or a synced directory, and ranks players by average WPM. Sessions shorter
+than 15 seconds or 60 characters are ignored, exactly as in statistics.
+
+Each player shares their history file under their own name:
+  <folder>/alice.jsonl            copy of alice's history.jsonl
+  <folder>/bob/history.jsonl      or one folder per player
+  <folder>/carol.json             a 'gti statistics --export' file
+
+flags:
+  --dir <folder>     shared folder with history files (required)
+  --mode <mode>      mode to show first, e.g. practice or quote (default: all)
+  --window <window>  week, month or all-time (default: all-time)
+  --json             print every ranking as JSON and exit
+
+CONTROLS:
+  h/l       Switch time window
+  j/k       Switch mode
+  q         Quit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lbFlags.dir == "" {
+			return fmt.Errorf("--dir is required")
+		}
+		window, err := leaderboard.ParseWindow(lbFlags.window)
+		if err != nil {
+			return err
+		}
+
+		dir := config.ExpandPath(lbFlags.dir)
+		players, problems, err := leaderboard.LoadDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to read leaderboard folder: %w", err)
+		}
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", problem)
+		}
+
+		if lbFlags.json {
+			return printLeaderboardJSON(dir, players, problems)
+		}
+		if len(players) == 0 {
+			return fmt.Errorf("no history files found in %s", dir)
+		}
+
+		model := tui.NewLeaderboardModel(config.GetConfig(), dir, players, lbFlags.mode, window)
+		if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+			return fmt.Errorf("failed to run leaderboard interface: %w", err)
+		}
+		return nil
+	},
+}
+
+func printLeaderboardJSON(dir string, players []*leaderboard.Player, problems []leaderboard.FileProblem) error {
+	now := time.Now()
+	boards := leaderboard.RankAll(players, now)
+	if lbFlags.mode != "" {
+		var filtered []leaderboard.Board
+		for _, b := range boards {
+			if b.Mode == lbFlags.mode {
+				filtered = append(filtered, b)
+			}
+		}
+		boards = filtered
+	}
+
+	output := map[string]interface{}{
+		"generated_at": now.Format(time.RFC3339),
+		"dir":          dir,
+		"players":      players,
+		"boards":       boards,
+	}
+	if len(problems) > 0 {
+		output["problems"] = problems
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func init() {
+	leaderboardCmd.Flags().StringVar(&lbFlags.dir, "dir", "", "shared folder with history files")
+	leaderboardCmd.Flags().StringVar(&lbFlags.mode, "mode", "", "mode to rank")
+	leaderboardCmd.Flags().StringVar(&lbFlags.window, "window", string(leaderboard.WindowAllTime), "time window: week, month or all-time")
+	leaderboardCmd.Flags().BoolVar(&lbFlags.json, "json", false, "print rankings as JSON")
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal/config"
+)
+
+var profileDeleteYes bool
+
+var profileCmd = &cobra.Command{
+	Use:   "profile <command>",
+	Short: "manage user profiles",
+	Long: `usage: gti profile <command>
+
+Profiles keep separate config, history, challenge progress and high scores
+for everyone sharing a machine. Each profile lives in its own directory
+under the data directory's profiles folder.
+
+commands:
+  create <name>    create a new profile
+  use <name>       make a profile the default ("default" for the shared one)
+  list             list profiles and mark the active one
+  delete <name>    delete a profile and all of its data
+  picker on|off    ask which profile to use when gti starts in a terminal
+
+flags:
+  --profile <name>  use a profile for a single run of any command`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "create a new profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.CreateProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Created profile '%s'. Switch to it with: gti profile use %s\n", args[0], args[0])
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "make a profile the default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if !config.ProfileExists(name) {
+			return fmt.Errorf("profile '%s' does not exist", name)
+		}
+
+		state, err := config.LoadProfileState()
+		if err != nil {
+			return err
+		}
+		state.Current = name
+		if name == config.DefaultProfile {
+			state.Current = ""
+		}
+		if err := config.SaveProfileState(state); err != nil {
+			return err
+		}
+		fmt.Printf("Now using profile '%s'\n", name)
+		return nil
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := config.ListProfiles()
+		if err != nil {
+			return err
+		}
+
+		active := config.Profile
+		if active == "" {
+			active = config.DefaultProfile
+		}
+		for _, name := range append([]string{config.DefaultProfile}, names...) {
+			marker := " "
+			if name == active {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, name)
+		}
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "delete a profile and its data",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := config.ValidateProfileName(name); err != nil {
+			return err
+		}
+		if !config.ProfileExists(name) {
+			return fmt.Errorf("profile '%s' does not exist", name)
+		}
+
+		if !profileDeleteYes {
+			fmt.Printf("Delete profile '%s' and all of its history? (y/N) ", name)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if err := config.DeleteProfile(name); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted profile '%s'\n", name)
+		return nil
+	},
+}
+
+var profilePickerCmd = &cobra.Command{
+	Use:       "picker on|off",
+	Short:     "toggle the startup profile picker",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		state, err := config.LoadProfileState()
+		if err != nil {
+			return err
+		}
+
+		switch args[0] {
+		case "on":
+			state.Picker = true
+		case "off":
+			state.Picker = false
+		default:
+			return fmt.Errorf("expected 'on' or 'off', got '%s'", args[0])
+		}
+		if err := config.SaveProfileState(state); err != nil {
+			return err
+		}
+		fmt.Printf("Profile picker turned %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	profileDeleteCmd.Flags().BoolVarP(&profileDeleteYes, "yes", "y", false, "delete without asking for confirmation")
+
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+	profileCmd.AddCommand(profilePickerCmd)
+}
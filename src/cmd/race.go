@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gti/src/internal/app"
+	"gti/src/internal/race"
+)
+
+var (
+	racePort      string
+	raceName      string
+	raceWords     int
+	raceCountdown int
+)
+
+var raceCmd = &cobra.Command{
+	Use:   "race <command>",
+	Short: "race other typists on the local network",
+	Long: `usage: gti race <command> [flags]
+
+commands:
+  host                host a race and pick the text
+  join <addr>         join a race hosted at addr (host or host:port)
+
+flags:
+  --name <name>       name shown to other racers (default: $USER)
+  --port <port>       port to listen on when hosting (default: 7878)
+  --words <num>       number of words in the race text (default: 30)
+  --countdown <sec>   countdown before the race starts (default: 3)
+
+Races run directly between machines over TCP, no external service is
+needed. Final standings are saved to races.jsonl in the data directory.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var raceHostCmd = &cobra.Command{
+	Use:   "host [flags]",
+	Short: "host a race on this machine",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.StartRaceHost(app.RaceOptions{
+			Addr:      net.JoinHostPort("", racePort),
+			Name:      raceName,
+			Words:     raceWords,
+			Countdown: raceCountdown,
+		})
+	},
+}
+
+var raceJoinCmd = &cobra.Command{
+	Use:   "join <addr>",
+	Short: "join a race hosted on the local network",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.StartRaceJoin(app.RaceOptions{
+			Addr: args[0],
+			Name: raceName,
+		})
+	},
+}
+
+func defaultRaceName() string {
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	if name := os.Getenv("USERNAME"); name != "" {
+		return name
+	}
+	return "player"
+}
+
+func init() {
+	raceCmd.PersistentFlags().StringVar(&raceName, "name", defaultRaceName(), "name shown to other racers")
+	raceHostCmd.Flags().StringVar(&racePort, "port", race.DefaultPort, "port to listen on")
+	raceHostCmd.Flags().IntVar(&raceWords, "words", 30, "number of words in the race text")
+	raceHostCmd.Flags().IntVar(&raceCountdown, "countdown", 3, "countdown in seconds before the race starts")
+
+	raceCmd.AddCommand(raceHostCmd)
+	raceCmd.AddCommand(raceJoinCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"gti/src/internal"
 	"gti/src/internal/app"
 	"gti/src/internal/config"
+	"gti/src/internal/tui"
 )
 
 var cfgFile string
@@ -17,6 +19,7 @@ var chunksPerGroup int
 var defaultGroups int
 var language string
 var startParagraph int
+var profileName string
 
 var rootCmd = &cobra.Command{
 	Use:   "gti",
@@ -37,7 +40,14 @@ QUICK START
 COMMANDS
   quote                  Start with random quotes
   challenge              Progressive challenge with levels
+  race <command>         Race other typists on the local network
+  duel                   Two-player hot-seat duel in one terminal
+  arcade                 Falling-words arcade game
+  profile <command>      Manage user profiles
+  leaderboard            Rank a team from a shared folder of histories
   statistics             View detailed typing statistics
+  goals [command]        Set and review practice goals
+  history <command>      Manage typing history
   theme <command>        Manage color themes
   config <command>       View and manage configuration
   version                Display version information
@@ -49,6 +59,7 @@ OPTIONS
   --start <num>          Start from paragraph number (for custom mode)
   -t, --timed <time>     Start timed mode with duration
   -s, --shortcuts        Show shortcuts and exit
+  --profile <name>       Use a profile for this run
   -h, --help             Display help information
   -v, --version          Display version information`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -101,7 +112,9 @@ func Execute() {
 
 func init() {
 	// init sets up the root command flags and subcommands
-	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return initConfig(cmd)
+	}
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = false
 
@@ -115,17 +128,95 @@ func init() {
 	rootCmd.Flags().StringP("timed", "t", "", "start timed mode with duration (e.g., 30, 10s, 5m)")
 	rootCmd.Flags().StringVarP(&language, "language", "l", "", "language for word generation (english, spanish, french, german, japanese, etc.)")
 	rootCmd.Flags().BoolP("shortcuts", "s", false, "show shortcuts and exit")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "use a profile for this run")
 
 	rootCmd.AddCommand(quoteCmd)
 	rootCmd.AddCommand(challengeCmd)
+	rootCmd.AddCommand(raceCmd)
+	rootCmd.AddCommand(duelCmd)
+	rootCmd.AddCommand(arcadeCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(leaderboardCmd)
+	rootCmd.AddCommand(historyCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(themeCmd)
 	rootCmd.AddCommand(statisticsCmd)
+	rootCmd.AddCommand(goalsCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
-func initConfig() {
+func initConfig(cmd *cobra.Command) error {
+	if err := selectProfile(cmd); err != nil {
+		return err
+	}
 	config.InitConfig(cfgFile)
+	return nil
+}
+
+// selectProfile picks the profile from --profile, the startup picker or the
+// last profile chosen with "gti profile use", in that order.
+func selectProfile(cmd *cobra.Command) error {
+	if profileName != "" {
+		return config.UseProfile(profileName)
+	}
+
+	state, err := config.LoadProfileState()
+	if err != nil {
+		return err
+	}
+
+	if state.Picker && isInteractive(cmd) && isTerminal() {
+		names, err := config.ListProfiles()
+		if err != nil {
+			return err
+		}
+		if len(names) > 0 {
+			choices := append([]string{config.DefaultProfile}, names...)
+			name, ok, err := tui.PickProfile(choices, state.Current)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				cmd.SilenceUsage = true
+				return errors.New("no profile selected")
+			}
+			return config.UseProfile(name)
+		}
+	}
+
+	if state.Current != "" && config.ProfileExists(state.Current) {
+		return config.UseProfile(state.Current)
+	}
+	return nil
+}
+
+// isInteractive reports whether cmd opens a typing or statistics screen.
+// Commands that print for scripts, such as --json output, exports and
+// shell completion, never show the profile picker.
+func isInteractive(cmd *cobra.Command) bool {
+	switch cmd {
+	case rootCmd, quoteCmd, challengeCmd, raceHostCmd, raceJoinCmd, duelCmd, arcadeCmd, statisticsCmd, leaderboardCmd:
+	default:
+		return false
+	}
+	for _, name := range []string{"json", "export", "shortcuts"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return false
+		}
+	}
+	return true
+}
+
+// isTerminal reports whether both stdin and stdout are attached to a
+// terminal, so a picker can be shown without hanging a pipe.
+func isTerminal() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func parseDuration(durationStr string) int {
@@ -160,6 +251,11 @@ func showShortcuts() error {
 	fmt.Println("  Up/Down       Scroll through content (in menus/views)")
 	fmt.Println("  PgUp/PgDn     Page up/down (in statistics view)")
 	fmt.Println()
+	fmt.Println("RESULTS SCREEN CONTROLS:")
+	fmt.Println("  Tab           Switch between summary and detail (WPM chart, errors, mistyped words)")
+	fmt.Println("  Enter         Start another session")
+	fmt.Println("  Esc           Exit")
+	fmt.Println()
 	fmt.Println("STATISTICS VIEW CONTROLS:")
 	fmt.Println("  q             Quit statistics view")
 	fmt.Println("  s             Switch between time views (session/daily/weekly/all-time)")
@@ -3,46 +3,28 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"os"
+	"path/filepath"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"gti/src/internal/analytics"
 	"gti/src/internal/config"
 	"gti/src/internal/session"
 	"gti/src/internal/tui"
 )
 
-type Statistics struct {
-	TotalSessions   int
-	TotalTime       time.Duration
-	RawAvgWPM       float64
-	RawPeakWPM      float64
-	RawAvgAccuracy  float64
-	RawBestAccuracy float64
-	AvgMistakes     float64
-	BackspaceRate   float64
-
-	ValidSessions        []*session.SessionRecord
-	NormalizedAvgWPM     float64
-	NormalizedPeakWPM    float64
-	RecentValidAvgWPM    float64
-	RecentValidCountUsed int
-
-	ConsistencyScore float64
-	ImprovementRate  float64
-	VariancePercent  float64
-	OutlierCount     int
-
-	CurrentStreak int
-	LongestStreak int
-}
-
 type statisticsCmdFlags struct {
-	view   string
-	export bool
-	json   bool
+	view     string
+	export   string
+	format   string
+	json     bool
+	from     string
+	to       string
+	last     string
+	mode     string
+	language string
 }
 
 var statsFlags statisticsCmdFlags
@@ -67,39 +49,56 @@ VIEWS:
 EXAMPLES:
   gti statistics                    # View all-time statistics
   gti statistics --view daily      # View today's performance
-  gti statistics --export          # Export data to Downloads folder
-  gti statistics --json            # Output machine-readable JSON
+  gti statistics --export report.html          # Self-contained HTML report with charts
+  gti statistics --export out.csv --view weekly # This week's sessions as CSV
+  gti statistics --format md                    # Markdown summary in Downloads
+  gti statistics --json                         # Output machine-readable JSON
+  gti statistics --last 30d --mode words        # Words sessions from the last 30 days
+  gti statistics --from 2024-05-01 --to 2024-05-31 --language go --json
+
+FILTERS:
+  --from, --to   A date (2024-05-01), an RFC 3339 time, or a span ago (7d)
+  --last         Only the last span of hours, days or weeks (12h, 30d, 4w)
+  --mode         Only sessions of this mode
+  --language     Only sessions typed in this language
+  In the viewer press f and type terms such as: mode:words lang:go last:30d
+
+EXPORT FORMATS:
+  csv       One row per session
+  md        Summary and session tables in Markdown
+  html      Single-file report with WPM and accuracy charts
+  json      Statistics and sessions as JSON (default)
 
 CONTROLS:
   q         Quit statistics view
   s         Switch between time views
   h/l       Navigate between views (vim-style)
-  e         Export current view data
+  c         Shade the calendar by minutes, sessions or average WPM
+  f or /    Edit the filter (Enter applies, empty clears, Esc cancels)
+  e         Export current view as JSON to Downloads
   ↑/↓       Scroll through statistics
   PgUp/PgDn Page scroll`,
 	DisableAutoGenTag: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.GetConfig()
 
-		if statsFlags.view != "" {
-			validViews := []string{"session", "daily", "weekly", "all-time"}
-			isValid := false
-			for _, v := range validViews {
-				if statsFlags.view == v {
-					isValid = true
-					break
-				}
-			}
-			if !isValid {
-				return fmt.Errorf("invalid view '%s'. Valid options: session, daily, weekly, all-time", statsFlags.view)
-			}
+		view, err := analytics.ParseView(statsFlags.view)
+		if err != nil {
+			return err
+		}
+		filter, err := statisticsFilter()
+		if err != nil {
+			return err
 		}
 
 		if statsFlags.json {
-			return exportStatisticsJSON(cfg, statsFlags.view)
+			return exportStatisticsJSON(cfg, view, filter)
+		}
+		if statsFlags.export != "" || statsFlags.format != "" {
+			return exportStatisticsFile(cfg, view, filter)
 		}
 
-		model := tui.NewStatisticsModel(cfg)
+		model := tui.NewStatisticsModel(cfg, view, filter)
 
 		p := tea.NewProgram(model, tea.WithAltScreen())
 
@@ -111,161 +110,102 @@ CONTROLS:
 	},
 }
 
-func exportStatisticsJSON(cfg *config.Config, viewFilter string) error {
-	records, err := session.LoadSessionRecords(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to load session records: %w", err)
+// statisticsFilter builds the history filter from the command line flags.
+func statisticsFilter() (analytics.Filter, error) {
+	filter := analytics.Filter{
+		Mode:     statsFlags.mode,
+		Language: statsFlags.language,
 	}
 
-	var filteredRecords []*session.SessionRecord
-	now := time.Now()
-
-	switch viewFilter {
-	case "session":
-		if len(records) > 0 {
-			filteredRecords = []*session.SessionRecord{records[0]}
-		}
-	case "daily":
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		for _, r := range records {
-			if !r.Timestamp.Before(today) {
-				filteredRecords = append(filteredRecords, r)
-			}
+	var err error
+	if statsFlags.from != "" {
+		if filter.From, err = analytics.ParseTime(statsFlags.from, false); err != nil {
+			return filter, err
 		}
-	case "weekly":
-		daysSinceMonday := int(now.Weekday() - time.Monday)
-		if daysSinceMonday < 0 {
-			daysSinceMonday += 7
-		}
-		monday := now.AddDate(0, 0, -daysSinceMonday)
-		monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
-		for _, r := range records {
-			if !r.Timestamp.Before(monday) {
-				filteredRecords = append(filteredRecords, r)
-			}
+	}
+	if statsFlags.to != "" {
+		if filter.To, err = analytics.ParseTime(statsFlags.to, true); err != nil {
+			return filter, err
 		}
-	default:
-		filteredRecords = records
 	}
-
-	stats := calculateStatistics(filteredRecords)
-
-	exportData := map[string]interface{}{
-		"view":       viewFilter,
-		"generated":  now.Format(time.RFC3339),
-		"statistics": stats,
-		"sessions":   filteredRecords,
+	if statsFlags.last != "" {
+		if filter.Last, err = analytics.ParseLast(statsFlags.last); err != nil {
+			return filter, err
+		}
 	}
-
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(exportData)
+	return filter, nil
 }
 
-func init() {
-	statisticsCmd.Flags().StringVar(&statsFlags.view, "view", "", "statistics view (session, daily, weekly, all-time)")
-	statisticsCmd.Flags().BoolVar(&statsFlags.export, "export", false, "export current view data to Downloads folder")
-	statisticsCmd.Flags().BoolVar(&statsFlags.json, "json", false, "output statistics in JSON format")
-}
-
-func calculateStatistics(records []*session.SessionRecord) *Statistics {
-	stats := &Statistics{}
-	totalSessions := len(records)
-	if totalSessions == 0 {
-		return stats
-	}
-
-	var totalWPM, totalAccuracy float64
-	var totalMistakes int
-	var totalDurationMs int64
-
-	for _, r := range records {
-		totalWPM += r.WPM
-		totalAccuracy += r.Accuracy
-		totalMistakes += r.Mistakes
-		totalDurationMs += r.DurationMs
-
-		if r.WPM > stats.RawPeakWPM {
-			stats.RawPeakWPM = r.WPM
-		}
-		if r.Accuracy > stats.RawBestAccuracy {
-			stats.RawBestAccuracy = r.Accuracy
+func exportStatisticsFile(cfg *config.Config, view analytics.View, filter analytics.Filter) error {
+	path := config.ExpandPath(statsFlags.export)
+	format := tui.ExportFormatForPath(path)
+	if statsFlags.format != "" {
+		var err error
+		if format, err = tui.ParseExportFormat(statsFlags.format); err != nil {
+			return err
 		}
 	}
-
-	stats.TotalSessions = totalSessions
-	stats.TotalTime = time.Duration(totalDurationMs) * time.Millisecond
-	stats.RawAvgWPM = totalWPM / float64(totalSessions)
-	stats.RawAvgAccuracy = totalAccuracy / float64(totalSessions)
-	stats.AvgMistakes = float64(totalMistakes) / float64(totalSessions)
-	stats.BackspaceRate = 0
-
-	valid := make([]*session.SessionRecord, 0, totalSessions)
-	for _, r := range records {
-		d := time.Duration(r.DurationMs) * time.Millisecond
-		if d >= 15*time.Second && r.TextLength >= 60 {
-			valid = append(valid, r)
-		}
+	if path == "" {
+		path = tui.DefaultExportPath(view, format)
+	} else if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, filepath.Base(tui.DefaultExportPath(view, format)))
 	}
-	stats.ValidSessions = valid
-	stats.OutlierCount = totalSessions - len(valid)
 
-	if len(valid) == 0 {
-		return stats
+	records, err := session.LoadSessionRecords(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load session records: %w", err)
 	}
+	records = analytics.FilterView(filter.Apply(records), view)
 
-	var sumValid float64
-	maxValid := 0.0
-	for _, r := range valid {
-		sumValid += r.WPM
-		if r.WPM > maxValid {
-			maxValid = r.WPM
-		}
+	if err := tui.ExportStatistics(path, format, view, filter, records); err != nil {
+		return fmt.Errorf("failed to export statistics: %w", err)
 	}
-	stats.NormalizedAvgWPM = sumValid / float64(len(valid))
-	stats.NormalizedPeakWPM = maxValid
+	fmt.Printf("Exported %d sessions to %s\n", len(records), path)
+	return nil
+}
 
-	recentN := 5
-	if len(valid) < recentN {
-		recentN = len(valid)
+func exportStatisticsJSON(cfg *config.Config, view analytics.View, filter analytics.Filter) error {
+	records, report, err := session.LoadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load session records: %w", err)
 	}
-	stats.RecentValidCountUsed = recentN
 
-	var recentSum float64
-	for i := 0; i < recentN; i++ {
-		recentSum += valid[i].WPM
-	}
-	stats.RecentValidAvgWPM = recentSum / float64(recentN)
+	filteredRecords := analytics.FilterView(filter.Apply(records), view)
+	stats := analytics.Calculate(filteredRecords)
 
-	if recentN >= 3 && stats.RecentValidAvgWPM > 0 {
-		var variance float64
-		for i := 0; i < recentN; i++ {
-			diff := valid[i].WPM - stats.RecentValidAvgWPM
-			variance += diff * diff
-		}
-		variance /= float64(recentN)
-		stdDev := math.Sqrt(variance)
-		stats.ConsistencyScore = (stdDev / stats.RecentValidAvgWPM) * 100
-		stats.VariancePercent = stats.ConsistencyScore
+	exportData := map[string]interface{}{
+		"view":       string(view),
+		"generated":  time.Now().Format(time.RFC3339),
+		"statistics": stats,
+		"sessions":   filteredRecords,
 	}
-
-	if len(valid) >= 10 {
-		half := len(valid) / 2
-
-		var newerSum, olderSum float64
-		for i := 0; i < half; i++ {
-			newerSum += valid[i].WPM
-			olderSum += valid[len(valid)-1-i].WPM
-		}
-
-		newerAvg := newerSum / float64(half)
-		olderAvg := olderSum / float64(half)
-		if olderAvg > 0 {
-			stats.ImprovementRate = ((newerAvg - olderAvg) / olderAvg) * 100
-		}
+	if !filter.IsEmpty() {
+		exportData["filter"] = filter.String()
+	}
+	if bests, err := session.LoadPersonalBests(cfg); err == nil {
+		exportData["personal_bests"] = session.SortedBests(bests)
+	}
+	if cfg.Goals.IsSet() {
+		progress := session.CalculateGoalProgress(cfg.Goals, records, time.Now())
+		exportData["goals"] = progress.Items()
+	}
+	if report.HasProblems() {
+		exportData["unreadable_lines"] = report.Unreadable
 	}
 
-	stats.CurrentStreak, stats.LongestStreak = session.CalculateStreaks(valid)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exportData)
+}
 
-	return stats
+func init() {
+	statisticsCmd.Flags().StringVar(&statsFlags.view, "view", "", "statistics view (session, daily, weekly, all-time)")
+	statisticsCmd.Flags().StringVar(&statsFlags.export, "export", "", "export the view to a file or directory")
+	statisticsCmd.Flags().StringVar(&statsFlags.format, "format", "", "export format: csv, md, html or json (default: from file extension)")
+	statisticsCmd.Flags().BoolVar(&statsFlags.json, "json", false, "output statistics in JSON format")
+	statisticsCmd.Flags().StringVar(&statsFlags.from, "from", "", "only sessions at or after this date, time or duration ago")
+	statisticsCmd.Flags().StringVar(&statsFlags.to, "to", "", "only sessions at or before this date, time or duration ago")
+	statisticsCmd.Flags().StringVar(&statsFlags.last, "last", "", "only sessions from the last span, such as 12h, 30d or 4w")
+	statisticsCmd.Flags().StringVar(&statsFlags.mode, "mode", "", "only sessions of this mode")
+	statisticsCmd.Flags().StringVar(&statsFlags.language, "language", "", "only sessions typed in this language")
 }
@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+// minSessionsForTrend is the fewest sessions a breakdown row needs before
+// its newer and older halves are compared.
+const minSessionsForTrend = 4
+
+// DurationBucket groups sessions of similar length so a 15 second test is
+// never averaged with an hour spent on a book.
+type DurationBucket struct {
+	Label string
+	Max   time.Duration
+}
+
+// DurationBuckets are in ascending order; the last has no upper bound. The
+// limits sit between the common timed test lengths.
+var DurationBuckets = []DurationBucket{
+	{"15s", 20 * time.Second},
+	{"30s", 45 * time.Second},
+	{"60s", 90 * time.Second},
+	{"90s-5m", 5 * time.Minute},
+	{"5-20m", 20 * time.Minute},
+	{"20m+", 0},
+}
+
+// BucketFor returns the index of the duration bucket a record falls in. The
+// time limit is used when there was one, otherwise how long it took.
+func BucketFor(r *session.SessionRecord) int {
+	d := time.Duration(r.DurationTargetMs) * time.Millisecond
+	if d == 0 {
+		d = time.Duration(r.DurationMs) * time.Millisecond
+	}
+	for i, b := range DurationBuckets {
+		if b.Max == 0 || d <= b.Max {
+			return i
+		}
+	}
+	return len(DurationBuckets) - 1
+}
+
+// BreakdownRow summarises the sessions of one mode, language and duration
+// bucket. Trend is the percentage change from the older half of the
+// sessions to the newer half, or zero with fewer than four sessions.
+type BreakdownRow struct {
+	Mode         string
+	Language     string
+	Duration     string
+	Sessions     int
+	AvgWPM       float64
+	BestWPM      float64
+	AvgAccuracy  float64
+	BestAccuracy float64
+	Trend        float64
+}
+
+// CalculateBreakdown groups records, newest first, by mode, language and
+// duration bucket. Rows are ordered by mode, then language, then bucket.
+func CalculateBreakdown(records []*session.SessionRecord) []BreakdownRow {
+	type key struct {
+		mode     string
+		language string
+		bucket   int
+	}
+	groups := make(map[key][]*session.SessionRecord)
+	for _, r := range records {
+		k := key{r.Mode, r.Language, BucketFor(r)}
+		groups[k] = append(groups[k], r)
+	}
+
+	keys := make([]key, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.mode != b.mode {
+			return a.mode < b.mode
+		}
+		if a.language != b.language {
+			return a.language < b.language
+		}
+		return a.bucket < b.bucket
+	})
+
+	rows := make([]BreakdownRow, 0, len(keys))
+	for _, k := range keys {
+		group := groups[k]
+		row := BreakdownRow{
+			Mode:     k.mode,
+			Language: k.language,
+			Duration: DurationBuckets[k.bucket].Label,
+			Sessions: len(group),
+		}
+		var sumWPM, sumAcc float64
+		for _, r := range group {
+			sumWPM += r.WPM
+			sumAcc += r.Accuracy
+			if r.WPM > row.BestWPM {
+				row.BestWPM = r.WPM
+			}
+			if r.Accuracy > row.BestAccuracy {
+				row.BestAccuracy = r.Accuracy
+			}
+		}
+		row.AvgWPM = sumWPM / float64(len(group))
+		row.AvgAccuracy = sumAcc / float64(len(group))
+		if len(group) >= minSessionsForTrend {
+			row.Trend = halfTrend(group)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
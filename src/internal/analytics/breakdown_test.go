@@ -0,0 +1,75 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+func TestBucketFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		target time.Duration
+		took   time.Duration
+		want   string
+	}{
+		{name: "15s test", target: 15 * time.Second, took: 15 * time.Second, want: "15s"},
+		{name: "limit wins over time taken", target: 60 * time.Second, took: 12 * time.Second, want: "60s"},
+		{name: "untimed on a boundary", took: 45 * time.Second, want: "30s"},
+		{name: "just past a boundary", took: 45*time.Second + time.Millisecond, want: "60s"},
+		{name: "a few minutes", took: 3 * time.Minute, want: "90s-5m"},
+		{name: "long text", took: 12 * time.Minute, want: "5-20m"},
+		{name: "book chapter", took: 2 * time.Hour, want: "20m+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &session.SessionRecord{DurationTargetMs: tt.target.Milliseconds(), DurationMs: tt.took.Milliseconds()}
+			if got := DurationBuckets[BucketFor(r)].Label; got != tt.want {
+				t.Errorf("BucketFor = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateBreakdown(t *testing.T) {
+	record := func(mode, language string, seconds int, wpm, accuracy float64) *session.SessionRecord {
+		return &session.SessionRecord{Mode: mode, Language: language, DurationMs: int64(seconds) * 1000, WPM: wpm, Accuracy: accuracy}
+	}
+	// Newest first, as history is loaded.
+	records := []*session.SessionRecord{
+		record("words", "english", 30, 66, 98),
+		record("timed", "german", 60, 40, 95),
+		record("words", "english", 30, 60, 96),
+		record("words", "english", 600, 50, 99),
+		record("words", "english", 30, 55, 94),
+		record("timed", "english", 60, 45, 97),
+		record("words", "english", 30, 50, 92),
+	}
+
+	want := []BreakdownRow{
+		{Mode: "timed", Language: "english", Duration: "60s", Sessions: 1, AvgWPM: 45, BestWPM: 45, AvgAccuracy: 97, BestAccuracy: 97},
+		{Mode: "timed", Language: "german", Duration: "60s", Sessions: 1, AvgWPM: 40, BestWPM: 40, AvgAccuracy: 95, BestAccuracy: 95},
+		// Newer half averages 63, older half 52.5: a 20% improvement.
+		{Mode: "words", Language: "english", Duration: "30s", Sessions: 4, AvgWPM: 57.75, BestWPM: 66, AvgAccuracy: 95, BestAccuracy: 98, Trend: 20},
+		{Mode: "words", Language: "english", Duration: "5-20m", Sessions: 1, AvgWPM: 50, BestWPM: 50, AvgAccuracy: 99, BestAccuracy: 99},
+	}
+
+	got := CalculateBreakdown(records)
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		trendOK := g.Trend-w.Trend < 1e-9 && w.Trend-g.Trend < 1e-9
+		g.Trend, w.Trend = 0, 0
+		if g != w || !trendOK {
+			t.Errorf("row %d = %+v (trend %.2f), want %+v (trend %.2f)", i, got[i], got[i].Trend, want[i], want[i].Trend)
+		}
+	}
+
+	if rows := CalculateBreakdown(nil); len(rows) != 0 {
+		t.Errorf("breakdown of no records = %+v, want no rows", rows)
+	}
+}
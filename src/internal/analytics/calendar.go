@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+// CalendarWeeks is how many whole weeks the calendar covers before the
+// current one.
+const CalendarWeeks = 52
+
+// CalendarLevels is the number of shades a day can take, including the
+// empty shade for days without practice.
+const CalendarLevels = 5
+
+type CalendarMetric string
+
+const (
+	CalendarMinutes  CalendarMetric = "minutes"
+	CalendarSessions CalendarMetric = "sessions"
+	CalendarWPM      CalendarMetric = "wpm"
+)
+
+var CalendarMetrics = []CalendarMetric{CalendarMinutes, CalendarSessions, CalendarWPM}
+
+func (c CalendarMetric) Label() string {
+	switch c {
+	case CalendarSessions:
+		return "sessions"
+	case CalendarWPM:
+		return "average WPM"
+	default:
+		return "minutes practised"
+	}
+}
+
+// Format renders a day's value in the metric's unit.
+func (c CalendarMetric) Format(value float64) string {
+	switch c {
+	case CalendarSessions:
+		return fmt.Sprintf("%.0f", value)
+	case CalendarWPM:
+		return fmt.Sprintf("%.1f wpm", value)
+	default:
+		if value < 10 {
+			return fmt.Sprintf("%.1f min", value)
+		}
+		return fmt.Sprintf("%.0f min", value)
+	}
+}
+
+func (c CalendarMetric) value(day *session.DayActivity) float64 {
+	switch c {
+	case CalendarSessions:
+		return float64(day.Sessions)
+	case CalendarWPM:
+		return day.AvgWPM()
+	default:
+		return day.Duration.Minutes()
+	}
+}
+
+type CalendarDay struct {
+	Date   time.Time
+	Value  float64
+	Level  int
+	Future bool
+}
+
+// Calendar is a grid of days, one column per week starting on Monday, with
+// the current week last.
+type Calendar struct {
+	Metric CalendarMetric
+	Weeks  [][7]CalendarDay
+	Max    float64
+	Total  float64
+	Active int
+}
+
+// BuildCalendar shades the last CalendarWeeks weeks of records by metric.
+// Levels are spread evenly between zero and the busiest day.
+func BuildCalendar(records []*session.SessionRecord, metric CalendarMetric, now time.Time) Calendar {
+	days := session.GroupByDay(records)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	daysSinceMonday := int(today.Weekday() - time.Monday)
+	if daysSinceMonday < 0 {
+		daysSinceMonday += 7
+	}
+	start := today.AddDate(0, 0, -daysSinceMonday-7*CalendarWeeks)
+
+	cal := Calendar{Metric: metric, Weeks: make([][7]CalendarDay, CalendarWeeks+1)}
+	for w := range cal.Weeks {
+		for d := 0; d < 7; d++ {
+			date := start.AddDate(0, 0, w*7+d)
+			day := CalendarDay{Date: date, Future: date.After(today)}
+			if activity, ok := days[session.DayKey(date)]; ok && !day.Future {
+				day.Value = metric.value(activity)
+			}
+			if day.Value > 0 {
+				cal.Active++
+				cal.Total += day.Value
+			}
+			if day.Value > cal.Max {
+				cal.Max = day.Value
+			}
+			cal.Weeks[w][d] = day
+		}
+	}
+
+	for w := range cal.Weeks {
+		for d := range cal.Weeks[w] {
+			cal.Weeks[w][d].Level = cal.level(cal.Weeks[w][d].Value)
+		}
+	}
+	return cal
+}
+
+func (c Calendar) level(value float64) int {
+	if value <= 0 || c.Max <= 0 {
+		return 0
+	}
+	level := int(math.Ceil(value / c.Max * float64(CalendarLevels-1)))
+	return min(max(level, 1), CalendarLevels-1)
+}
@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+func TestBuildCalendar(t *testing.T) {
+	// A Wednesday, so the current week has two days past and four to come.
+	now := time.Date(2026, 1, 7, 18, 0, 0, 0, time.UTC)
+	day := func(year int, month time.Month, d, hour int) time.Time {
+		return time.Date(year, month, d, hour, 0, 0, 0, time.UTC)
+	}
+	record := func(at time.Time, duration time.Duration, chars int, wpm float64) *session.SessionRecord {
+		return &session.SessionRecord{Timestamp: at, DurationMs: duration.Milliseconds(), TextLength: chars, WPM: wpm}
+	}
+	records := []*session.SessionRecord{
+		record(day(2026, 1, 9, 9), 10*time.Minute, 200, 90),
+		record(day(2026, 1, 7, 12), 20*time.Minute, 200, 60),
+		// Too short to count towards speed.
+		record(day(2026, 1, 7, 9), 6*time.Second, 20, 100),
+		record(day(2026, 1, 5, 9), 10*time.Minute, 200, 40),
+		record(day(2025, 1, 6, 9), 5*time.Minute, 200, 30),
+		record(day(2025, 1, 5, 9), 30*time.Minute, 200, 80),
+	}
+
+	type shade struct {
+		value float64
+		level int
+	}
+	tests := []struct {
+		metric CalendarMetric
+		today  shade
+		monday shade
+		first  shade
+		total  float64
+	}{
+		{metric: CalendarMinutes, today: shade{20.1, 4}, monday: shade{10, 2}, first: shade{5, 1}, total: 35.1},
+		{metric: CalendarSessions, today: shade{2, 4}, monday: shade{1, 2}, first: shade{1, 2}, total: 4},
+		{metric: CalendarWPM, today: shade{60, 4}, monday: shade{40, 3}, first: shade{30, 2}, total: 130},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			cal := BuildCalendar(records, tt.metric, now)
+			if len(cal.Weeks) != CalendarWeeks+1 {
+				t.Fatalf("calendar has %d weeks, want %d", len(cal.Weeks), CalendarWeeks+1)
+			}
+
+			first := cal.Weeks[0][0]
+			if !first.Date.Equal(day(2025, 1, 6, 0)) {
+				t.Errorf("calendar starts on %s, want Monday 2025-01-06", first.Date.Format("2006-01-02"))
+			}
+			current := cal.Weeks[CalendarWeeks]
+			for d, want := range []bool{false, false, false, true, true, true, true} {
+				if current[d].Future != want {
+					t.Errorf("%s future = %v, want %v", current[d].Date.Format("Mon"), current[d].Future, want)
+				}
+				if current[d].Future && current[d].Value != 0 {
+					t.Errorf("future day %s has value %v", current[d].Date.Format("2006-01-02"), current[d].Value)
+				}
+			}
+
+			for name, check := range map[string]struct {
+				got  CalendarDay
+				want shade
+			}{
+				"today":  {current[2], tt.today},
+				"monday": {current[0], tt.monday},
+				"first":  {first, tt.first},
+			} {
+				if !closeTo(check.got.Value, check.want.value) || check.got.Level != check.want.level {
+					t.Errorf("%s = %.2f at level %d, want %.2f at level %d", name, check.got.Value, check.got.Level, check.want.value, check.want.level)
+				}
+			}
+			if cal.Active != 3 || !closeTo(cal.Total, tt.total) || !closeTo(cal.Max, tt.today.value) {
+				t.Errorf("calendar active %d total %.2f max %.2f, want 3, %.2f and %.2f", cal.Active, cal.Total, cal.Max, tt.total, tt.today.value)
+			}
+			if empty := current[1]; empty.Value != 0 || empty.Level != 0 {
+				t.Errorf("day without practice = %+v, want level 0", empty)
+			}
+		})
+	}
+}
+
+func TestBuildCalendarEmpty(t *testing.T) {
+	cal := BuildCalendar(nil, CalendarMinutes, time.Date(2026, 1, 11, 12, 0, 0, 0, time.UTC))
+	if cal.Active != 0 || cal.Max != 0 {
+		t.Errorf("empty calendar = active %d max %v, want nothing", cal.Active, cal.Max)
+	}
+	// On a Sunday the whole current week is in the past.
+	for _, d := range cal.Weeks[CalendarWeeks] {
+		if d.Future || d.Level != 0 {
+			t.Errorf("%s = %+v, want a past empty day", d.Date.Format("2006-01-02"), d)
+		}
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
@@ -0,0 +1,238 @@
+package analytics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+type View string
+
+const (
+	ViewAllTime View = "all-time"
+	ViewWeekly  View = "weekly"
+	ViewDaily   View = "daily"
+	ViewSession View = "session"
+)
+
+var Views = []View{ViewSession, ViewDaily, ViewWeekly, ViewAllTime}
+
+func ParseView(name string) (View, error) {
+	if name == "" {
+		return ViewAllTime, nil
+	}
+	for _, v := range Views {
+		if string(v) == name {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid view '%s'. Valid options: session, daily, weekly, all-time", name)
+}
+
+// FilterView returns the records a view covers. Records must be newest
+// first.
+func FilterView(records []*session.SessionRecord, view View) []*session.SessionRecord {
+	now := time.Now()
+
+	switch view {
+	case ViewSession:
+		if len(records) > 0 {
+			return []*session.SessionRecord{records[0]}
+		}
+		return []*session.SessionRecord{}
+
+	case ViewDaily:
+		var daily []*session.SessionRecord
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		for _, r := range records {
+			if !r.Timestamp.Before(today) {
+				daily = append(daily, r)
+			}
+		}
+		return daily
+
+	case ViewWeekly:
+		var weekly []*session.SessionRecord
+		daysSinceMonday := int(now.Weekday() - time.Monday)
+		if daysSinceMonday < 0 {
+			daysSinceMonday += 7
+		}
+		monday := now.AddDate(0, 0, -daysSinceMonday)
+		monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
+
+		for _, r := range records {
+			if !r.Timestamp.Before(monday) {
+				weekly = append(weekly, r)
+			}
+		}
+		return weekly
+
+	case ViewAllTime:
+		fallthrough
+	default:
+		return records
+	}
+}
+
+// Filter narrows history before statistics are calculated. Zero values match
+// everything. From and To are inclusive; Last keeps sessions newer than that
+// long ago and combines with From by using whichever is later.
+type Filter struct {
+	From     time.Time
+	To       time.Time
+	Last     time.Duration
+	Mode     string
+	Language string
+}
+
+func (f Filter) IsEmpty() bool {
+	return f == Filter{}
+}
+
+// Query turns the filter into a history query as of now.
+func (f Filter) Query(now time.Time) session.Query {
+	q := session.Query{
+		Since:    f.From,
+		Until:    f.To,
+		Mode:     f.Mode,
+		Language: f.Language,
+	}
+	if f.Last > 0 {
+		if since := now.Add(-f.Last); since.After(q.Since) {
+			q.Since = since
+		}
+	}
+	return q
+}
+
+// Apply returns the records that match, keeping their order.
+func (f Filter) Apply(records []*session.SessionRecord) []*session.SessionRecord {
+	if f.IsEmpty() {
+		return records
+	}
+	q := f.Query(time.Now())
+	matched := []*session.SessionRecord{}
+	for _, r := range records {
+		if q.Matches(r) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// String formats the filter in the syntax ParseFilter reads.
+func (f Filter) String() string {
+	var parts []string
+	if f.Mode != "" {
+		parts = append(parts, "mode:"+f.Mode)
+	}
+	if f.Language != "" {
+		parts = append(parts, "lang:"+f.Language)
+	}
+	if !f.From.IsZero() {
+		parts = append(parts, "from:"+formatFilterTime(f.From))
+	}
+	if !f.To.IsZero() {
+		parts = append(parts, "to:"+formatFilterTime(f.To))
+	}
+	if f.Last > 0 {
+		parts = append(parts, "last:"+FormatLast(f.Last))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatFilterTime(t time.Time) string {
+	switch {
+	case t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0:
+		return t.Format("2006-01-02")
+	case t.Hour() == 23 && t.Minute() == 59 && t.Second() == 59 && t.Nanosecond() == int(time.Second-1):
+		return t.Format("2006-01-02")
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
+
+// ParseFilter reads space separated key:value terms such as
+// "mode:words lang:go last:30d". Keys are mode, lang (or language), from, to
+// and last. An empty string is the empty filter.
+func ParseFilter(text string) (Filter, error) {
+	var f Filter
+	for _, term := range strings.Fields(text) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			return f, fmt.Errorf("invalid filter '%s': use key:value with mode, lang, from, to or last", term)
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "mode":
+			f.Mode = value
+		case "lang", "language":
+			f.Language = value
+		case "from", "since":
+			f.From, err = ParseTime(value, false)
+		case "to", "until":
+			f.To, err = ParseTime(value, true)
+		case "last":
+			f.Last, err = ParseLast(value)
+		default:
+			return f, fmt.Errorf("unknown filter key '%s'. Valid keys: mode, lang, from, to, last", key)
+		}
+		if err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// ParseTime accepts a date, an RFC 3339 time or a duration ago such as 7d.
+// A bare date used as an upper bound covers the whole day.
+func ParseTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if endOfDay {
+			return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+		return t, nil
+	}
+	if d, err := ParseLast(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s': use a date like 2024-05-01, an RFC 3339 time, or a duration like 7d", value)
+}
+
+// ParseLast reads a span such as 12h, 30d or 4w.
+func ParseLast(value string) (time.Duration, error) {
+	if len(value) > 1 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'h':
+				return time.Duration(n) * time.Hour, nil
+			case 'd':
+				return time.Duration(n) * 24 * time.Hour, nil
+			case 'w':
+				return time.Duration(n) * 7 * 24 * time.Hour, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid duration '%s': use hours, days or weeks like 12h, 30d or 4w", value)
+}
+
+// FormatLast is the inverse of ParseLast, using the largest whole unit.
+func FormatLast(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d%(7*day) == 0:
+		return fmt.Sprintf("%dw", d/(7*day))
+	case d%day == 0:
+		return fmt.Sprintf("%dd", d/day)
+	default:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+}
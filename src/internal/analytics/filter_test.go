@@ -0,0 +1,158 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+func TestParseFilter(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.Local)
+	}
+	endOf := func(d int) time.Time {
+		return day(d + 1).Add(-time.Nanosecond)
+	}
+
+	tests := []struct {
+		text    string
+		want    Filter
+		wantErr bool
+	}{
+		{text: "", want: Filter{}},
+		{text: "mode:words lang:go", want: Filter{Mode: "words", Language: "go"}},
+		{text: "MODE:timed language:german", want: Filter{Mode: "timed", Language: "german"}},
+		{text: "from:2026-01-02 to:2026-01-05", want: Filter{From: day(2), To: endOf(5)}},
+		{text: "since:2026-01-02 until:2026-01-02", want: Filter{From: day(2), To: endOf(2)}},
+		{text: "from:2026-01-02T10:00:00Z", want: Filter{From: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}},
+		{text: "last:12h", want: Filter{Last: 12 * time.Hour}},
+		{text: "last:30d mode:quote", want: Filter{Last: 30 * 24 * time.Hour, Mode: "quote"}},
+		{text: "last:2w", want: Filter{Last: 14 * 24 * time.Hour}},
+		{text: "mode", wantErr: true},
+		{text: "mode:", wantErr: true},
+		{text: "wpm:50", wantErr: true},
+		{text: "from:yesterday", wantErr: true},
+		{text: "last:30", wantErr: true},
+		{text: "last:-3d", wantErr: true},
+		{text: "last:3m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseFilter(tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseFilter(%q) = %+v, want an error", tt.text, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tt.text, err)
+			}
+			if !got.From.Equal(tt.want.From) || !got.To.Equal(tt.want.To) {
+				t.Errorf("ParseFilter(%q) range = %v to %v, want %v to %v", tt.text, got.From, got.To, tt.want.From, tt.want.To)
+			}
+			got.From, got.To, tt.want.From, tt.want.To = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+			if got != tt.want {
+				t.Errorf("ParseFilter(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterRelativeTime(t *testing.T) {
+	f, err := ParseFilter("from:7d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ago := time.Since(f.From); ago < 7*24*time.Hour || ago > 7*24*time.Hour+time.Minute {
+		t.Errorf("from:7d starts %v ago, want 7 days", ago)
+	}
+}
+
+func TestFilterStringRoundTrip(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"mode:words",
+		"mode:timed lang:german from:2026-01-02 to:2026-01-05",
+		"from:2026-01-02T10:30:00Z",
+		"last:36h",
+		"last:3d",
+		"last:2w",
+	} {
+		f, err := ParseFilter(text)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", text, err)
+		}
+		again, err := ParseFilter(f.String())
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", f.String(), err)
+		}
+		if !again.From.Equal(f.From) || !again.To.Equal(f.To) || again.Mode != f.Mode || again.Language != f.Language || again.Last != f.Last {
+			t.Errorf("%q read back from %q as %+v, want %+v", text, f.String(), again, f)
+		}
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	now := time.Now()
+	records := []*session.SessionRecord{
+		{Timestamp: now.Add(-time.Hour), Mode: "words", Language: "english", WPM: 1},
+		{Timestamp: now.Add(-50 * time.Hour), Mode: "timed", Language: "german", WPM: 2},
+		{Timestamp: now.Add(-10 * 24 * time.Hour), Mode: "words", Language: "german", WPM: 3},
+		{Timestamp: now.Add(-40 * 24 * time.Hour), Mode: "quote", Language: "english", WPM: 4},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []float64
+	}{
+		{name: "empty", filter: Filter{}, want: []float64{1, 2, 3, 4}},
+		{name: "mode", filter: Filter{Mode: "words"}, want: []float64{1, 3}},
+		{name: "language", filter: Filter{Language: "german"}, want: []float64{2, 3}},
+		{name: "mode and language", filter: Filter{Mode: "words", Language: "german"}, want: []float64{3}},
+		{name: "last", filter: Filter{Last: 3 * 24 * time.Hour}, want: []float64{1, 2}},
+		{name: "last wins over an earlier from", filter: Filter{From: now.Add(-30 * 24 * time.Hour), Last: 24 * time.Hour}, want: []float64{1}},
+		{name: "from wins over a longer last", filter: Filter{From: now.Add(-3 * 24 * time.Hour), Last: 30 * 24 * time.Hour}, want: []float64{1, 2}},
+		{name: "bounds are inclusive", filter: Filter{From: records[2].Timestamp, To: records[1].Timestamp}, want: []float64{2, 3}},
+		{name: "no match", filter: Filter{Mode: "race"}, want: []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.Apply(records)
+			if got == nil {
+				t.Fatal("Apply returned nil")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Apply kept %d records, want %d", len(got), len(tt.want))
+			}
+			for i, r := range got {
+				if r.WPM != tt.want[i] {
+					t.Errorf("record %d = %v, want %v", i, r.WPM, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatLast(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{5 * time.Hour, "5h"},
+		{36 * time.Hour, "36h"},
+		{day, "1d"},
+		{10 * day, "10d"},
+		{14 * day, "2w"},
+	}
+	for _, tt := range tests {
+		if got := FormatLast(tt.d); got != tt.want {
+			t.Errorf("FormatLast(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package analytics
+
+import (
+	"math"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+const (
+	recentSessionsCount       = 5
+	MinSessionsForVariance    = 3
+	minSessionsForImprovement = 10
+)
+
+type Statistics struct {
+	TotalSessions   int
+	TotalTime       time.Duration
+	RawAvgWPM       float64
+	RawPeakWPM      float64
+	RawAvgAccuracy  float64
+	RawBestAccuracy float64
+	AvgMistakes     float64
+	BackspaceRate   float64
+
+	ValidSessions        []*session.SessionRecord
+	NormalizedAvgWPM     float64
+	NormalizedPeakWPM    float64
+	RecentValidAvgWPM    float64
+	RecentValidCountUsed int
+
+	NetAvgWPM            float64
+	NetPeakWPM           float64
+	AdjustedAvgWPM       float64
+	AdjustedPeakWPM      float64
+	AvgCorrectedErrors   float64
+	AvgUncorrectedErrors float64
+
+	ConsistencyScore float64
+	ImprovementRate  float64
+	VariancePercent  float64
+	OutlierCount     int
+
+	CurrentStreak int
+	LongestStreak int
+
+	Breakdown []BreakdownRow
+
+	// Typos adds up the typo breakdown of the TypoSessions sessions that
+	// recorded one.
+	Typos        session.TypoSummary
+	TypoSessions int
+}
+
+// Calculate summarises records, which must be newest first. Speed figures
+// only use sessions that pass session.IsValidRecord.
+func Calculate(records []*session.SessionRecord) *Statistics {
+	stats := &Statistics{}
+	totalSessions := len(records)
+	if totalSessions == 0 {
+		return stats
+	}
+
+	calculateBasicStats(records, stats)
+
+	for _, r := range records {
+		if r.Typos != nil {
+			stats.Typos.Add(*r.Typos)
+			stats.TypoSessions++
+		}
+	}
+
+	valid := session.FilterValidSessions(records)
+	stats.ValidSessions = valid
+	stats.OutlierCount = totalSessions - len(valid)
+
+	if len(valid) == 0 {
+		return stats
+	}
+
+	calculateNormalizedStats(valid, stats)
+
+	calculateRecentPerformance(valid, stats)
+
+	calculateImprovementRate(valid, stats)
+
+	stats.CurrentStreak, stats.LongestStreak = session.CalculateStreaks(valid)
+
+	stats.Breakdown = CalculateBreakdown(valid)
+
+	return stats
+}
+
+func calculateBasicStats(records []*session.SessionRecord, stats *Statistics) {
+	totalSessions := len(records)
+	var totalWPM, totalAccuracy float64
+	var totalMistakes int
+	var totalDurationMs int64
+	var totalBackspaces int
+	var totalCorrectedErrors, totalUncorrectedErrors int
+
+	for _, r := range records {
+		totalWPM += r.WPM
+		totalAccuracy += r.Accuracy
+		totalMistakes += r.Mistakes
+		totalDurationMs += r.DurationMs
+		totalBackspaces += r.BackspaceCount
+		totalCorrectedErrors += r.CorrectedErrors
+		totalUncorrectedErrors += r.UncorrectedErrors
+
+		if r.WPM > stats.RawPeakWPM {
+			stats.RawPeakWPM = r.WPM
+		}
+		if r.Accuracy > stats.RawBestAccuracy {
+			stats.RawBestAccuracy = r.Accuracy
+		}
+	}
+
+	stats.TotalSessions = totalSessions
+	stats.TotalTime = time.Duration(totalDurationMs) * time.Millisecond
+	stats.RawAvgWPM = totalWPM / float64(totalSessions)
+	stats.RawAvgAccuracy = totalAccuracy / float64(totalSessions)
+	stats.AvgMistakes = float64(totalMistakes) / float64(totalSessions)
+	stats.BackspaceRate = float64(totalBackspaces) / float64(totalSessions)
+	stats.AvgCorrectedErrors = float64(totalCorrectedErrors) / float64(totalSessions)
+	stats.AvgUncorrectedErrors = float64(totalUncorrectedErrors) / float64(totalSessions)
+}
+
+func calculateNormalizedStats(valid []*session.SessionRecord, stats *Statistics) {
+	var sumValid, sumNetWPM, sumAdjustedWPM float64
+	var maxValid, maxNetWPM, maxAdjustedWPM float64
+
+	for _, r := range valid {
+		sumValid += r.WPM
+		sumNetWPM += r.NetWPM
+		sumAdjustedWPM += r.AdjustedWPM
+
+		if r.WPM > maxValid {
+			maxValid = r.WPM
+		}
+		if r.NetWPM > maxNetWPM {
+			maxNetWPM = r.NetWPM
+		}
+		if r.AdjustedWPM > maxAdjustedWPM {
+			maxAdjustedWPM = r.AdjustedWPM
+		}
+	}
+
+	stats.NormalizedAvgWPM = sumValid / float64(len(valid))
+	stats.NormalizedPeakWPM = maxValid
+	stats.NetAvgWPM = sumNetWPM / float64(len(valid))
+	stats.NetPeakWPM = maxNetWPM
+	stats.AdjustedAvgWPM = sumAdjustedWPM / float64(len(valid))
+	stats.AdjustedPeakWPM = maxAdjustedWPM
+}
+
+func calculateRecentPerformance(valid []*session.SessionRecord, stats *Statistics) {
+	recentN := recentSessionsCount
+	if len(valid) < recentN {
+		recentN = len(valid)
+	}
+	stats.RecentValidCountUsed = recentN
+
+	var recentSum float64
+	for i := 0; i < recentN; i++ {
+		recentSum += valid[i].WPM
+	}
+	stats.RecentValidAvgWPM = recentSum / float64(recentN)
+
+	if recentN >= MinSessionsForVariance && stats.RecentValidAvgWPM > 0 {
+		var variance float64
+		for i := 0; i < recentN; i++ {
+			diff := valid[i].WPM - stats.RecentValidAvgWPM
+			variance += diff * diff
+		}
+		variance /= float64(recentN)
+		stdDev := math.Sqrt(variance)
+		stats.ConsistencyScore = (stdDev / stats.RecentValidAvgWPM) * 100
+		stats.VariancePercent = stats.ConsistencyScore
+	}
+}
+
+func calculateImprovementRate(valid []*session.SessionRecord, stats *Statistics) {
+	if len(valid) >= minSessionsForImprovement {
+		stats.ImprovementRate = halfTrend(valid)
+	}
+}
+
+// halfTrend compares the average WPM of the newer half of records with the
+// older half, as a percentage. Records must be newest first.
+func halfTrend(records []*session.SessionRecord) float64 {
+	half := len(records) / 2
+	if half == 0 {
+		return 0
+	}
+
+	var newerSum, olderSum float64
+	for i := 0; i < half; i++ {
+		newerSum += records[i].WPM
+		olderSum += records[len(records)-1-i].WPM
+	}
+
+	newerAvg := newerSum / float64(half)
+	olderAvg := olderSum / float64(half)
+	if olderAvg <= 0 {
+		return 0
+	}
+	return ((newerAvg - olderAvg) / olderAvg) * 100
+}
@@ -1,10 +1,12 @@
 package app
 
 import (
-	"fmt"
-
+	"gti/src/internal"
+	"gti/src/internal/arcade"
 	"gti/src/internal/challenge"
 	"gti/src/internal/config"
+	"gti/src/internal/duel"
+	"gti/src/internal/race"
 	"gti/src/internal/session"
 	"gti/src/internal/tui"
 
@@ -93,37 +95,85 @@ func StartTimed(seconds int) error {
 	return runTUIModel(cfg, tui.ModelOptions{Mode: "timed", Seconds: seconds})
 }
 
-func StartChallengeGame() error {
-	levels := []challenge.Level{}
-
-	for i, level := range challenge.GetBuiltInLevels() {
-		challengeLevel := challenge.Level{
-			Name:        level.Name,
-			Difficulty:  fmt.Sprintf("lv%d", i+1),
-			Time:        level.TimeSeconds,
-			ChunkSize:   10,
-			Message:     "Level completed!",
-			IsBoss:      level.IsBoss,
-			MinAccuracy: level.MinAccuracy,
-			MaxMistakes: level.MaxMistakes,
-			MinChars:    level.MinChars,
-			MinWords:    level.MinWords,
-		}
+func StartChallengeGame(pack string) error {
+	defs := challenge.GetBuiltInLevels()
+	packID := ""
 
-		if level.IsBoss {
-			words := level.MinChars / 5
-			if words < 1 {
-				words = 1
-			}
-			challengeLevel.BossRound = &challenge.BossRound{
-				Words:     words,
-				TimeLimit: level.TimeSeconds,
-				Name:      level.Name,
-			}
+	if pack != "" {
+		levelPack, err := challenge.LoadPack(pack)
+		if err != nil {
+			return err
 		}
+		defs = levelPack.Levels
+		packID = levelPack.ID
+	}
+
+	return challenge.StartChallengeGame(challenge.BuildLevels(defs), packID)
+}
+
+func StartAdaptiveChallenge() error {
+	return challenge.StartAdaptiveChallenge()
+}
 
-		levels = append(levels, challengeLevel)
+func StartSurvival() error {
+	return challenge.StartSurvival()
+}
+
+type RaceOptions struct {
+	Addr      string
+	Name      string
+	Words     int
+	Countdown int
+}
+
+func StartRaceHost(opts RaceOptions) error {
+	cfg := config.GetConfig()
+	words := opts.Words
+	if words <= 0 {
+		words = 30
 	}
 
-	return challenge.StartChallengeGame(levels)
+	return race.StartHost(cfg, race.HostOptions{
+		Addr:      opts.Addr,
+		Name:      opts.Name,
+		Countdown: opts.Countdown,
+		Text:      internal.GenerateWordsDynamic(words, cfg.Language.Default),
+		Language:  cfg.Language.Default,
+	})
+}
+
+func StartRaceJoin(opts RaceOptions) error {
+	cfg := config.GetConfig()
+	return race.StartJoin(cfg, opts.Addr, opts.Name)
+}
+
+type DuelOptions struct {
+	Player1 string
+	Player2 string
+	Rounds  int
+	Words   int
+	Seed    int64
+}
+
+func StartDuel(opts DuelOptions) error {
+	cfg := config.GetConfig()
+	return duel.StartDuel(cfg, duel.Options{
+		Players: [2]string{opts.Player1, opts.Player2},
+		Rounds:  opts.Rounds,
+		Words:   opts.Words,
+		Seed:    opts.Seed,
+	})
+}
+
+type ArcadeOptions struct {
+	Language string
+	Lives    int
+}
+
+func StartArcade(opts ArcadeOptions) error {
+	cfg := config.GetConfig()
+	return arcade.StartArcade(cfg, arcade.Options{
+		Language: opts.Language,
+		Lives:    opts.Lives,
+	})
 }
@@ -0,0 +1,525 @@
+// Package arcade implements a falling-words game: words drop from the top of
+// the screen and are destroyed by typing them before they reach the bottom.
+package arcade
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"gti/src/internal"
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	tickInterval      = 100 * time.Millisecond
+	startLives        = 3
+	startSpeed        = 1.0 // rows per second
+	speedGrowth       = 1.15
+	startSpawnEvery   = 2500 * time.Millisecond
+	spawnGrowth       = 0.9
+	minSpawnEvery     = 500 * time.Millisecond
+	baseWaveSize      = 10
+	waveSizeGrowth    = 5
+	maxPlacementTries = 5
+)
+
+type Options struct {
+	Language string
+	Lives    int
+}
+
+type fallingWord struct {
+	Text string
+	X    int
+	Y    float64
+}
+
+type tickMsg struct {
+	ID int
+}
+
+type Model struct {
+	config *config.Config
+	opts   Options
+	phase  string
+	width  int
+	height int
+
+	words      []fallingWord
+	input      string
+	lives      int
+	wave       int
+	waveLeft   int
+	speed      float64
+	spawnEvery time.Duration
+	lastSpawn  time.Time
+	lastTick   time.Time
+	tickID     int
+
+	startTime  time.Time
+	endTime    time.Time
+	score      int
+	destroyed  int
+	chars      int
+	keystrokes int
+	mistakes   int
+
+	rank    int
+	scores  []Score
+	saveErr error
+}
+
+func NewModel(cfg *config.Config, opts Options) Model {
+	if opts.Language == "" {
+		opts.Language = cfg.Language.Default
+	}
+	if opts.Lives <= 0 {
+		opts.Lives = startLives
+	}
+
+	m := Model{
+		config: cfg,
+		opts:   opts,
+		phase:  "ready",
+	}
+	m.scores = m.loadTable()
+	return m
+}
+
+func (m *Model) reset() {
+	now := time.Now()
+	m.phase = "playing"
+	m.words = nil
+	m.input = ""
+	m.lives = m.opts.Lives
+	m.wave = 1
+	m.waveLeft = baseWaveSize
+	m.speed = startSpeed
+	m.spawnEvery = startSpawnEvery
+	m.lastSpawn = time.Time{}
+	m.lastTick = now
+	m.startTime = now
+	m.score, m.destroyed, m.chars = 0, 0, 0
+	m.keystrokes, m.mistakes = 0, 0
+	m.rank = 0
+	m.saveErr = nil
+	m.tickID++
+}
+
+func (m Model) tick() tea.Cmd {
+	id := m.tickID
+	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+		return tickMsg{ID: id}
+	})
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.EnterAltScreen
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tickMsg:
+		if msg.ID != m.tickID || m.phase != "playing" {
+			return m, nil
+		}
+		return m.handleTick()
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m *Model) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.phase {
+	case "ready", "over":
+		switch key.String() {
+		case "enter", " ":
+			m.reset()
+			return m, m.tick()
+		case "q", "esc":
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch key.Type {
+	case tea.KeyEsc:
+		m.input = ""
+	case tea.KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case tea.KeyRunes:
+		for _, r := range key.Runes {
+			m.typeRune(r)
+		}
+	}
+	return m, nil
+}
+
+// typeRune extends the input when some word still starts with it. Anything
+// else counts as a mistake and is not added.
+func (m *Model) typeRune(r rune) {
+	m.keystrokes++
+
+	candidate := m.input + string(r)
+	if m.target(candidate) < 0 {
+		m.mistakes++
+		return
+	}
+	m.input = candidate
+
+	for i, word := range m.words {
+		if word.Text == m.input {
+			m.destroy(i)
+			return
+		}
+	}
+}
+
+// target returns the lowest word starting with prefix, or -1.
+func (m Model) target(prefix string) int {
+	best := -1
+	for i, word := range m.words {
+		if !strings.HasPrefix(word.Text, prefix) {
+			continue
+		}
+		if best < 0 || word.Y > m.words[best].Y {
+			best = i
+		}
+	}
+	return best
+}
+
+func (m *Model) destroy(i int) {
+	word := m.words[i]
+	m.words = append(m.words[:i], m.words[i+1:]...)
+	m.input = ""
+	m.destroyed++
+	m.chars += len(word.Text) + 1
+	m.score += len(word.Text) * 10 * m.wave
+	m.finishWaveWord()
+}
+
+func (m *Model) finishWaveWord() {
+	m.waveLeft--
+	if m.waveLeft > 0 {
+		return
+	}
+	m.wave++
+	m.waveLeft = baseWaveSize + waveSizeGrowth*(m.wave-1)
+	m.speed *= speedGrowth
+	m.spawnEvery = time.Duration(float64(m.spawnEvery) * spawnGrowth)
+	if m.spawnEvery < minSpawnEvery {
+		m.spawnEvery = minSpawnEvery
+	}
+}
+
+func (m Model) fieldHeight() int {
+	return m.height - 3
+}
+
+func (m *Model) handleTick() (tea.Model, tea.Cmd) {
+	now := time.Now()
+	elapsed := now.Sub(m.lastTick).Seconds()
+	m.lastTick = now
+
+	bottom := float64(m.fieldHeight())
+	kept := m.words[:0]
+	for _, word := range m.words {
+		word.Y += m.speed * elapsed
+		if word.Y >= bottom {
+			m.lives--
+			m.finishWaveWord()
+			if strings.HasPrefix(word.Text, m.input) {
+				m.input = ""
+			}
+			continue
+		}
+		kept = append(kept, word)
+	}
+	m.words = kept
+
+	if m.lives <= 0 {
+		m.endGame()
+		return m, nil
+	}
+
+	if now.Sub(m.lastSpawn) >= m.spawnEvery {
+		m.spawn()
+		m.lastSpawn = now
+	}
+
+	return m, m.tick()
+}
+
+func (m *Model) spawn() {
+	text := internal.GenerateWord(m.opts.Language)
+	maxX := m.width - len([]rune(text))
+	if maxX < 1 {
+		maxX = 1
+	}
+
+	x := rand.Intn(maxX)
+	for try := 0; try < maxPlacementTries && m.overlapsTop(x, len([]rune(text))); try++ {
+		x = rand.Intn(maxX)
+	}
+	m.words = append(m.words, fallingWord{Text: text, X: x})
+}
+
+func (m Model) overlapsTop(x, length int) bool {
+	for _, word := range m.words {
+		if word.Y >= 2 {
+			continue
+		}
+		end := word.X + len([]rune(word.Text))
+		if x <= end && word.X <= x+length {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) accuracy() float64 {
+	if m.keystrokes == 0 {
+		return 100
+	}
+	return float64(m.keystrokes-m.mistakes) / float64(m.keystrokes) * 100
+}
+
+func (m *Model) endGame() {
+	m.phase = "over"
+	m.endTime = time.Now()
+	m.tickID++
+
+	duration := m.endTime.Sub(m.startTime)
+	wpm := session.CalculateWPM(m.chars, duration)
+	accuracy := m.accuracy()
+
+	if m.score > 0 {
+		m.rank, _ = AddScore(m.opts.Language, Score{
+			Score:    m.score,
+			Wave:     m.wave,
+			Words:    m.destroyed,
+			WPM:      wpm,
+			Accuracy: accuracy,
+		})
+		m.scores = m.loadTable()
+	}
+
+	m.saveErr = session.SaveSessionRecord(m.config, &session.SessionRecord{
+		Mode:       "arcade",
+		Tier:       fmt.Sprintf("wave%d", m.wave),
+		Language:   m.opts.Language,
+		TextSource: "words",
+		TextLength: m.chars,
+		DurationMs: duration.Milliseconds(),
+		WPM:        wpm,
+		CPM:        wpm * 5,
+		Accuracy:   accuracy,
+		Mistakes:   m.mistakes,
+	})
+}
+
+func (m Model) loadTable() []Score {
+	scores, err := LoadScores()
+	if err != nil {
+		return nil
+	}
+	return scores[m.opts.Language]
+}
+
+func (m Model) View() string {
+	if m.width < 40 || m.height < 10 {
+		return "Terminal too small. Please resize to at least 40x10.\nPress Ctrl+C to quit."
+	}
+
+	switch m.phase {
+	case "playing":
+		return m.viewPlaying()
+	case "over":
+		return m.viewOver()
+	default:
+		return m.viewReady()
+	}
+}
+
+func (m Model) style(color string) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(color)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background))
+}
+
+func (m Model) viewPlaying() string {
+	colors := m.config.Theme.Colors
+
+	status := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.TextPrimary)).
+		Background(lipgloss.Color(colors.StatusBar)).
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render(fmt.Sprintf("Arcade (%s) | Wave %d | Score %d | Lives %s",
+			m.opts.Language, m.wave, m.score, strings.Repeat("♥", m.lives)))
+
+	rows := make([][]fallingWord, m.fieldHeight())
+	for _, word := range m.words {
+		row := int(word.Y)
+		if row >= 0 && row < len(rows) {
+			rows[row] = append(rows[row], word)
+		}
+	}
+
+	targeted := -1
+	if m.input != "" {
+		targeted = m.target(m.input)
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		sort.Slice(row, func(i, j int) bool { return row[i].X < row[j].X })
+
+		var line strings.Builder
+		col := 0
+		for _, word := range row {
+			if word.X > col {
+				line.WriteString(m.style(colors.Pending).Render(strings.Repeat(" ", word.X-col)))
+				col = word.X
+			}
+
+			if targeted >= 0 && word == m.words[targeted] {
+				typed := len(m.input)
+				line.WriteString(m.style(colors.Correct).Bold(true).Render(word.Text[:typed]))
+				line.WriteString(m.style(colors.WordHighlight).Bold(true).Render(word.Text[typed:]))
+			} else {
+				color := colors.Pending
+				if float64(len(rows))-word.Y < 3 {
+					color = colors.Incorrect
+				}
+				line.WriteString(m.style(color).Render(word.Text))
+			}
+			col += len([]rune(word.Text))
+		}
+		lines = append(lines, lipgloss.NewStyle().
+			Width(m.width).
+			Background(lipgloss.Color(colors.Background)).
+			Render(line.String()))
+	}
+
+	ground := m.style(colors.Incorrect).Render(strings.Repeat("▔", m.width))
+	input := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.TextPrimary)).
+		Background(lipgloss.Color(colors.Background)).
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render("> " + m.input + "_")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		status,
+		strings.Join(lines, "\n"),
+		ground,
+		input,
+	)
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(colors.Background)).
+		Render(content)
+}
+
+func (m Model) viewReady() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Arcade - %s\n\n", m.opts.Language))
+	b.WriteString("Type the falling words before they hit the ground.\n")
+	b.WriteString(fmt.Sprintf("You have %d lives. Each wave falls faster.\n\n", m.opts.Lives))
+	b.WriteString(m.scoreTable())
+	b.WriteString("\nPress Enter to start, Q to quit")
+	return m.renderDialog(b.String())
+}
+
+func (m Model) viewOver() string {
+	duration := m.endTime.Sub(m.startTime)
+
+	var b strings.Builder
+	b.WriteString("Game Over\n\n")
+	b.WriteString(fmt.Sprintf("Score: %d\n", m.score))
+	b.WriteString(fmt.Sprintf("Wave: %d\n", m.wave))
+	b.WriteString(fmt.Sprintf("Words: %d\n", m.destroyed))
+	b.WriteString(fmt.Sprintf("WPM: %.1f\n", session.CalculateWPM(m.chars, duration)))
+	b.WriteString(fmt.Sprintf("Accuracy: %.1f%%\n", m.accuracy()))
+	if m.rank > 0 {
+		b.WriteString(fmt.Sprintf("\nNew high score! Rank #%d\n", m.rank))
+	}
+	if m.saveErr != nil {
+		b.WriteString(fmt.Sprintf("\nNot saved to history: %v\n", m.saveErr))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.scoreTable())
+	b.WriteString("\nPress Enter to play again, Q to quit")
+	return m.renderDialog(b.String())
+}
+
+func (m Model) scoreTable() string {
+	if len(m.scores) == 0 {
+		return "No high scores yet\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("High Scores (%s)\n", m.opts.Language))
+	b.WriteString(fmt.Sprintf("%-3s %7s %5s %6s %7s\n", "#", "Score", "Wave", "Words", "WPM"))
+	for i, score := range m.scores {
+		marker := " "
+		if i+1 == m.rank {
+			marker = "▶"
+		}
+		b.WriteString(fmt.Sprintf("%s%-2d %7d %5d %6d %7.1f\n", marker, i+1, score.Score, score.Wave, score.Words, score.WPM))
+	}
+	return b.String()
+}
+
+func (m Model) renderDialog(content string) string {
+	styledContent := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(content)
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		BorderBackground(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Padding(1, 2).
+		Render(styledContent)
+
+	placedBox := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color(m.config.Theme.Colors.Background)))
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(placedBox)
+}
+
+func StartArcade(cfg *config.Config, opts Options) error {
+	model := NewModel(cfg, opts)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
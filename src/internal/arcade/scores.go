@@ -0,0 +1,83 @@
+package arcade
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+const scoreLimit = 10
+
+type Score struct {
+	Timestamp time.Time `json:"timestamp"`
+	Score     int       `json:"score"`
+	Wave      int       `json:"wave"`
+	Words     int       `json:"words"`
+	WPM       float64   `json:"wpm"`
+	Accuracy  float64   `json:"accuracy"`
+}
+
+func scoresFile() string {
+	return filepath.Join(config.UserDataDir(), "arcade_scores.json")
+}
+
+// LoadScores returns the high-score tables keyed by language.
+func LoadScores() (map[string][]Score, error) {
+	scores := make(map[string][]Score)
+
+	data, err := os.ReadFile(scoresFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return scores, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return make(map[string][]Score), nil
+	}
+	return scores, nil
+}
+
+// AddScore records a game in the language's table and returns its 1-based
+// rank, or 0 when it did not make the table.
+func AddScore(language string, score Score) (int, error) {
+	scores, err := LoadScores()
+	if err != nil {
+		return 0, err
+	}
+
+	score.Timestamp = time.Now()
+	table := append(scores[language], score)
+	sort.SliceStable(table, func(i, j int) bool {
+		return table[i].Score > table[j].Score
+	})
+
+	rank := 0
+	for i := range table {
+		if table[i].Timestamp.Equal(score.Timestamp) && table[i].Score == score.Score {
+			rank = i + 1
+			break
+		}
+	}
+	if len(table) > scoreLimit {
+		table = table[:scoreLimit]
+	}
+	if rank > scoreLimit {
+		rank = 0
+	}
+	scores[language] = table
+
+	if err := os.MkdirAll(filepath.Dir(scoresFile()), 0755); err != nil {
+		return rank, err
+	}
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return rank, err
+	}
+	return rank, os.WriteFile(scoresFile(), data, 0644)
+}
@@ -0,0 +1,133 @@
+package challenge
+
+import (
+	"fmt"
+	"math"
+
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+)
+
+const (
+	adaptiveHistorySize   = 20
+	adaptiveDefaultWPM    = 30.0
+	adaptiveDefaultAcc    = 94.0
+	adaptiveStartRating   = 50.0
+	adaptiveRatingStep    = 20.0
+	adaptiveTargetPass    = 0.7
+	adaptiveMinRating     = 0.0
+	adaptiveMaxRating     = 100.0
+	adaptiveMinAccuracy   = 85.0
+	adaptiveMaxAccuracy   = 99.5
+	adaptiveBaseTime      = 30
+	adaptiveChunkSize     = 10
+	adaptiveMistakeMargin = 2
+	adaptiveBaselineBlend = 0.3
+)
+
+// AdaptiveDirector generates challenge levels on the fly. Difficulty is a
+// rating from 0 to 100 that rises after a pass and falls after a fail, with
+// steps sized so that the player settles at TargetPassRate.
+type AdaptiveDirector struct {
+	Rating           float64
+	PreviousRating   float64
+	TargetPassRate   float64
+	BaselineWPM      float64
+	BaselineAccuracy float64
+	Attempts         int
+	Passes           int
+}
+
+// NewAdaptiveDirector seeds the baseline from the most recent sessions in
+// history, falling back to beginner defaults when there is none.
+func NewAdaptiveDirector(cfg *config.Config) *AdaptiveDirector {
+	d := &AdaptiveDirector{
+		Rating:           adaptiveStartRating,
+		PreviousRating:   adaptiveStartRating,
+		TargetPassRate:   adaptiveTargetPass,
+		BaselineWPM:      adaptiveDefaultWPM,
+		BaselineAccuracy: adaptiveDefaultAcc,
+	}
+
+	records, err := session.LoadSessionRecords(cfg)
+	if err != nil {
+		return d
+	}
+
+	var sumWPM, sumAcc float64
+	count := 0
+	for _, record := range records {
+		if !session.IsValidRecord(record) {
+			continue
+		}
+		sumWPM += record.WPM
+		sumAcc += record.Accuracy
+		count++
+		if count == adaptiveHistorySize {
+			break
+		}
+	}
+	if count > 0 {
+		d.BaselineWPM = sumWPM / float64(count)
+		d.BaselineAccuracy = sumAcc / float64(count)
+	}
+
+	return d
+}
+
+// NextLevel builds the level for the current rating. A rating of 50 asks for
+// the player's baseline speed; 0 and 100 ask for 70% and 130% of it.
+func (d *AdaptiveDirector) NextLevel(number int) Level {
+	scale := 0.7 + 0.6*d.Rating/100
+	targetWPM := math.Round(d.BaselineWPM*scale*2) / 2
+
+	seconds := adaptiveBaseTime + int(d.Rating/5)
+	minChars := int(targetWPM * 5 * float64(seconds) / 60)
+
+	accuracy := d.BaselineAccuracy - 4 + d.Rating/25
+	accuracy = math.Max(adaptiveMinAccuracy, math.Min(adaptiveMaxAccuracy, accuracy))
+	accuracy = math.Round(accuracy*10) / 10
+
+	return Level{
+		Name:        fmt.Sprintf("Adaptive %d - Rating %.0f", number, d.Rating),
+		Difficulty:  fmt.Sprintf("adaptive-%.0f", d.Rating),
+		Time:        seconds,
+		ChunkSize:   adaptiveChunkSize,
+		Message:     "Level completed!",
+		MinAccuracy: accuracy,
+		MaxMistakes: int(float64(minChars)*(100-accuracy)/100) + adaptiveMistakeMargin,
+		MinChars:    minChars,
+		MinWords:    minChars / 5,
+		MinWPM:      targetWPM,
+	}
+}
+
+// Record moves the rating by the difference between the outcome and the
+// expected pass rate, so passes nudge it up and fails pull it down harder.
+// The level's speed and accuracy are blended into the baseline as an
+// exponential moving average, so levels follow how the player types today
+// rather than only their history.
+func (d *AdaptiveDirector) Record(passed bool, wpm, accuracy float64) {
+	if wpm > 0 {
+		d.BaselineWPM += adaptiveBaselineBlend * (wpm - d.BaselineWPM)
+		d.BaselineAccuracy += adaptiveBaselineBlend * (accuracy - d.BaselineAccuracy)
+	}
+
+	d.Attempts++
+	outcome := 0.0
+	if passed {
+		d.Passes++
+		outcome = 1
+	}
+
+	d.PreviousRating = d.Rating
+	d.Rating += adaptiveRatingStep * (outcome - d.TargetPassRate)
+	d.Rating = math.Max(adaptiveMinRating, math.Min(adaptiveMaxRating, d.Rating))
+}
+
+func (d *AdaptiveDirector) PassRate() float64 {
+	if d.Attempts == 0 {
+		return 0
+	}
+	return float64(d.Passes) / float64(d.Attempts) * 100
+}
@@ -3,6 +3,7 @@ package challenge
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ type BossRound struct {
 	TimeLimit    int
 	Name         string
 	TriggerChunk int
+	Modifiers    []string
 }
 
 type Level struct {
@@ -34,6 +36,10 @@ type Level struct {
 	MaxMistakes int
 	MinChars    int
 	MinWords    int
+	MinWPM      float64
+	MinNetWPM   float64
+	Language    string
+	TextSource  string
 }
 
 type GameState struct {
@@ -48,26 +54,68 @@ type GameState struct {
 	Mistakes       int
 	TotalChars     int
 	BossResults    []BossResult
+
+	TickID            int
+	LevelBossStart    int
+	ActiveBoss        *BossRound
+	BossesTriggered   map[int]bool
+	PausedTimeLeft    int
+	BossStartTime     time.Time
+	BossStartChars    int
+	BossStartMistakes int
+	ModifierStart     time.Time
 }
 
 type BossResult struct {
-	Name      string
-	WPM       float64
-	Accuracy  float64
-	Completed bool
+	Name       string
+	WPM        float64
+	Accuracy   float64
+	Mistakes   int
+	Chars      int
+	DurationMs int64
+	Hidden     bool
+	Completed  bool
 }
 
 type GameModel struct {
-	config  *config.Config
-	state   *GameState
-	sess    *session.Session
+	config    *config.Config
+	state     *GameState
+	sess      *session.Session
+	pack      string
+	progress  *GameProgress
+	mapCursor int
+	adaptive  *AdaptiveDirector
+	saveErr   error
 	width   int
 	height  int
 	mode    string
 }
 
-func NewGameModel(cfg *config.Config, levels []Level) GameModel {
-	startingLevel := GetStartingLevel(cfg)
+func NewGameModel(cfg *config.Config, levels []Level, pack string) GameModel {
+	startingLevel := GetStartingLevel(cfg, pack)
+	if startingLevel >= len(levels) {
+		startingLevel = len(levels) - 1
+	}
+
+	model := newGameModel(cfg, levels, startingLevel)
+	model.pack = pack
+	model.progress, _ = LoadProgress(cfg, pack)
+	model.mapCursor = startingLevel
+	model.state.Phase = "map"
+
+	return model
+}
+
+// NewAdaptiveGameModel starts an endless run whose levels are generated by
+// an AdaptiveDirector instead of read from a table.
+func NewAdaptiveGameModel(cfg *config.Config) GameModel {
+	director := NewAdaptiveDirector(cfg)
+	model := newGameModel(cfg, []Level{director.NextLevel(1)}, 0)
+	model.adaptive = director
+	return model
+}
+
+func newGameModel(cfg *config.Config, levels []Level, startingLevel int) GameModel {
 	now := time.Now()
 
 	state := &GameState{
@@ -84,9 +132,10 @@ func NewGameModel(cfg *config.Config, levels []Level) GameModel {
 	sess := session.NewSessionWithChallenge(cfg, fmt.Sprintf("lv%d", state.CurrentLevel+1))
 
 	model := GameModel{
-		config: cfg,
-		state:  state,
-		sess:   sess,
+		config:   cfg,
+		state:    state,
+		sess:     sess,
+		progress: &GameProgress{},
 	}
 
 	currentLevel := levels[startingLevel]
@@ -110,6 +159,9 @@ func (m GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case session.SessionCompleteMsg:
 		return m.handleSessionComplete()
 	case TickMsg:
+		if msg.ID != m.state.TickID {
+			return m, nil
+		}
 		return m.handleTick()
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -128,6 +180,10 @@ func (m GameModel) View() string {
 		return m.viewQuit()
 	default:
 		switch m.state.Phase {
+		case "map":
+			return m.viewLevelMap()
+		case "intro":
+			return m.viewLevelIntro()
 		case "complete":
 			return m.viewLevelComplete()
 		case "failed":
@@ -139,8 +195,19 @@ func (m GameModel) View() string {
 }
 
 func (m GameModel) viewNormalPlay() string {
+	if m.state.Phase == "boss" && m.state.ActiveBoss != nil {
+		return m.viewBossPlay()
+	}
 
-	content := m.sess.View(m.width, m.height)
+	var content string
+	if m.sess.HideErrors {
+		content = m.sess.View(m.width, m.height)
+	} else {
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			m.sess.View(m.width, m.height-1),
+			m.renderChecklist(),
+		)
+	}
 
 	return lipgloss.NewStyle().
 		Width(m.width).
@@ -149,6 +216,133 @@ func (m GameModel) viewNormalPlay() string {
 		Render(content)
 }
 
+// renderChecklist shows each level requirement against the live totals,
+// including the chunk currently being typed.
+func (m GameModel) renderChecklist() string {
+	level := m.state.Levels[m.state.CurrentLevel]
+	chars, mistakes, words := m.chunkTotals()
+	chars += m.state.TotalChars
+	mistakes += m.state.Mistakes
+	words += m.state.WordsTyped
+	elapsed := time.Since(m.state.LevelStartTime)
+	wpm := session.CalculateWPM(chars, elapsed)
+	accuracy := session.CalculateAccuracy(chars, mistakes)
+	if chars == 0 {
+		accuracy = 100
+	}
+
+	item := func(met bool, text string) string {
+		color := m.config.Theme.Colors.Incorrect
+		mark := "✗"
+		if met {
+			color = m.config.Theme.Colors.Correct
+			mark = "✓"
+		}
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(color)).
+			Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+			Render(mark + " " + text)
+	}
+
+	items := []string{}
+	if level.MinWPM > 0 {
+		items = append(items, item(wpm >= level.MinWPM, fmt.Sprintf("WPM %.0f/%.0f", wpm, level.MinWPM)))
+	}
+	if level.MinNetWPM > 0 {
+		netWPM := session.CalculateNetWPM(chars, mistakes, elapsed)
+		items = append(items, item(netWPM >= level.MinNetWPM, fmt.Sprintf("Net %.0f/%.0f", netWPM, level.MinNetWPM)))
+	}
+	items = append(items,
+		item(accuracy >= level.MinAccuracy, fmt.Sprintf("Acc %.1f/%.1f%%", accuracy, level.MinAccuracy)),
+		item(mistakes <= level.MaxMistakes, fmt.Sprintf("Mistakes %d/%d", mistakes, level.MaxMistakes)),
+		item(chars >= level.MinChars, fmt.Sprintf("Chars %d/%d", chars, level.MinChars)),
+		item(words >= level.MinWords, fmt.Sprintf("Words %d/%d", words, level.MinWords)),
+	)
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Align(lipgloss.Center).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(strings.Join(items, "  "))
+}
+
+// chunkTotals is what has been typed of the current chunk so far: the
+// characters, the mistakes and the words.
+func (m GameModel) chunkTotals() (int, int, int) {
+	typed := m.sess.TypedText()
+	return len(typed), m.sess.GetMistakes(), len(strings.Fields(typed))
+}
+
+// foldChunk adds the chunk in progress to the level totals when time runs
+// out part way through it, so the requirements are judged on the same
+// numbers the checklist showed.
+func (m *GameModel) foldChunk() {
+	chars, mistakes, words := m.chunkTotals()
+	m.state.TotalChars += chars
+	m.state.Mistakes += mistakes
+	m.state.WordsTyped += words
+}
+
+func (m GameModel) viewBossPlay() string {
+	boss := m.state.ActiveBoss
+	banner := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Incorrect)).
+		Bold(true).
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render(fmt.Sprintf("⚔ HIDDEN BOSS: %s ⚔  %ds left%s", boss.Name, m.state.TimeLeft, formatModifiers(boss.Modifiers)))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, banner, m.sess.View(m.width, m.height-1))
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(content)
+}
+
+func (m GameModel) viewLevelIntro() string {
+	level := m.state.Levels[m.state.CurrentLevel]
+	requirements := m.getLevelRequirements(level)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Level %d: %s\n\n", m.state.CurrentLevel+1, level.Name))
+	b.WriteString(fmt.Sprintf("Time: %ds\n", level.Time))
+	if requirements.MinWPM > 0 {
+		b.WriteString(fmt.Sprintf("Min WPM: %.0f\n", requirements.MinWPM))
+	}
+	b.WriteString(fmt.Sprintf("Min accuracy: %.1f%%\n", requirements.MinAccuracy))
+	b.WriteString(fmt.Sprintf("Max mistakes: %d\n", requirements.MaxMistakes))
+
+	if level.BossRound != nil {
+		b.WriteString(fmt.Sprintf("\n⚔ Boss: %s\n", level.BossRound.Name))
+		writeModifierList(&b, level.BossRound.Modifiers)
+	}
+	if len(level.BossRounds) > 0 {
+		b.WriteString(fmt.Sprintf("\n⚔ %d hidden boss(es) lurk in this level\n", len(level.BossRounds)))
+		for _, boss := range level.BossRounds {
+			writeModifierList(&b, boss.Modifiers)
+		}
+	}
+
+	b.WriteString("\nPress Enter to start")
+	return m.renderLevelDialog(b.String(), m.config.Theme.Colors.Incorrect)
+}
+
+func writeModifierList(b *strings.Builder, modifiers []string) {
+	for _, name := range modifiers {
+		b.WriteString(fmt.Sprintf("  • %s: %s\n", name, ModifierDescription(name)))
+	}
+}
+
+func formatModifiers(modifiers []string) string {
+	if len(modifiers) == 0 {
+		return ""
+	}
+	return "  [" + strings.Join(modifiers, ", ") + "]"
+}
+
 func (m GameModel) viewLevelComplete() string {
 	level := m.state.Levels[m.state.CurrentLevel]
 
@@ -172,10 +366,33 @@ Boss Rounds: %d/%d completed`,
 		len(m.state.BossResults),
 	)
 
+	for _, result := range m.levelBossResults() {
+		if !result.Hidden {
+			continue
+		}
+		status := "defeated"
+		if !result.Completed {
+			status = "escaped"
+		}
+		content += fmt.Sprintf("\n⚔ %s: %.1f WPM, %.1f%% (%s)", result.Name, result.WPM, result.Accuracy, status)
+	}
+
+	content += m.saveErrNote()
+
+	if m.adaptive != nil {
+		content += "\n\n" + m.adaptiveSummary()
+		content += "\n\nPress Enter for the next level, Ctrl+Q to stop"
+		return m.renderLevelDialog(content, m.config.Theme.Colors.TextPrimary)
+	}
+
+	if score, ok := m.progress.Levels[m.state.CurrentLevel]; ok {
+		content += fmt.Sprintf("\n\n%s  Best: %.1f WPM, %.1f%%", renderStars(score.Stars), score.BestWPM, score.BestAccuracy)
+	}
+
 	if m.state.CurrentLevel < len(m.state.Levels)-1 {
-		content += "\n\nPress Enter to continue to next level..."
+		content += "\n\nPress Enter to continue to next level, M for the level map..."
 	} else {
-		content += "\n\nCongratulations! All levels completed!"
+		content += "\n\nCongratulations! All levels completed!\nPress M for the level map"
 	}
 
 	return m.renderLevelDialog(content, m.config.Theme.Colors.TextPrimary)
@@ -189,25 +406,57 @@ func (m GameModel) viewLevelFailed() string {
 	content := fmt.Sprintf(`❌ Level %d Failed!
 
 Your Stats:
+WPM: %.1f (Required: %.1f)
+Net WPM: %.1f (Required: %.1f)
 Accuracy: %.1f%% (Required: %.1f%%)
 Mistakes: %d (Max allowed: %d)
 Chars Typed: %d (Required: %d)
 Words Typed: %d (Required: %d)
 
-Requirements not met. Try again!
-
-Press R to retry this level
-Press Q to quit`,
+Requirements not met. Try again!`,
 		m.state.CurrentLevel+1,
+		m.calculateWPM(), requirements.MinWPM,
+		m.calculateNetWPM(), requirements.MinNetWPM,
 		m.calculateAccuracy(), requirements.MinAccuracy,
 		m.state.Mistakes, requirements.MaxMistakes,
 		m.state.TotalChars, level.MinChars,
 		m.state.WordsTyped, requirements.MinWords,
 	)
+	content += m.saveErrNote()
+
+	if m.adaptive != nil {
+		content += "\n\n" + m.adaptiveSummary()
+		content += "\n\nPress R to retry at the new difficulty\nPress Q to quit"
+	} else {
+		content += "\n\nPress R to retry this level\nPress M for the level map\nPress Q to quit"
+	}
 
 	return m.renderLevelDialog(content, "red")
 }
 
+// saveErrNote explains that a result from this level did not reach history
+// or the level progress, or is empty when everything was saved.
+func (m GameModel) saveErrNote() string {
+	if m.saveErr == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n\nNot saved to %v", m.saveErr)
+}
+
+func (m GameModel) adaptiveSummary() string {
+	d := m.adaptive
+	arrow := "="
+	if d.Rating > d.PreviousRating {
+		arrow = "▲"
+	} else if d.Rating < d.PreviousRating {
+		arrow = "▼"
+	}
+	return fmt.Sprintf("Difficulty: %.0f → %.0f %s\nPass rate: %.0f%% (%d/%d, target %.0f%%)\nBaseline: %.1f WPM, %.1f%%",
+		d.PreviousRating, d.Rating, arrow,
+		d.PassRate(), d.Passes, d.Attempts, d.TargetPassRate*100,
+		d.BaselineWPM, d.BaselineAccuracy)
+}
+
 func (m GameModel) viewHelp() string {
 	helpText := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
@@ -283,23 +532,50 @@ func (m *GameModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "ctrl+h":
 			m.mode = "help"
 			return m, nil
-		case "esc":
-			return m.retryLevel()
 		}
 
 		switch m.state.Phase {
+		case "map":
+			return m.handleMapKey(key)
+		case "intro":
+			switch key.String() {
+			case "enter", " ":
+				return m.startLevel()
+			case "esc", "q":
+				if m.adaptive == nil {
+					return m.openLevelMap()
+				}
+				return m, tea.Quit
+			}
+			return m, nil
 		case "complete":
-			if key.String() == "enter" {
+			switch key.String() {
+			case "enter":
 				return m.advanceLevel()
+			case "m":
+				if m.adaptive == nil {
+					return m.openLevelMap()
+				}
 			}
 			return m, nil
 		case "failed":
-			if key.String() == "r" {
+			switch key.String() {
+			case "r":
 				return m.retryLevel()
+			case "m":
+				if m.adaptive == nil {
+					return m.openLevelMap()
+				}
+			case "q":
+				return m, tea.Quit
 			}
 			return m, nil
 		}
 
+		if key.String() == "esc" {
+			return m.retryLevel()
+		}
+
 		cmd := m.sess.HandleInput(key)
 		return m, cmd
 	}
@@ -314,28 +590,7 @@ func (m *GameModel) handleSessionComplete() (tea.Model, tea.Cmd) {
 	m.state.TotalChars += len(m.sess.TypedText())
 
 	if m.state.Phase == "boss" {
-		var bossName string
-		if level.BossRound != nil {
-			bossName = level.BossRound.Name
-		} else {
-			for _, boss := range level.BossRounds {
-				if boss.TriggerChunk == m.state.ChunkIndex {
-					bossName = boss.Name
-					break
-				}
-			}
-		}
-
-		result := BossResult{
-			Name:      bossName,
-			WPM:       m.calculateWPM(),
-			Accuracy:  m.calculateAccuracy(),
-			Completed: true,
-		}
-		m.state.BossResults = append(m.state.BossResults, result)
-		m.state.Phase = "normal"
-		m.state.ChunkIndex++
-		m.generateNextChunk()
+		m.finishHiddenBossRound(true)
 	} else if level.BossRound != nil {
 		if m.checkLevelRequirements(level) {
 			result := BossResult{
@@ -345,23 +600,47 @@ func (m *GameModel) handleSessionComplete() (tea.Model, tea.Cmd) {
 				Completed: true,
 			}
 			m.state.BossResults = append(m.state.BossResults, result)
-			m.state.Phase = "complete"
+			m.completeLevel()
 		} else {
-			m.state.Phase = "failed"
+			m.failLevel()
 		}
 	} else {
 		// For non-boss levels, always continue to next chunk until time runs out
 		m.state.ChunkIndex++
-		m.generateNextChunk()
+		if boss := m.pendingHiddenBoss(level); boss != nil {
+			m.startHiddenBossRound(*boss)
+		} else {
+			m.generateNextChunk()
+		}
 	}
 
 	return m, nil
 }
 
 func (m *GameModel) handleTick() (tea.Model, tea.Cmd) {
-	m.state.TimeLeft--
+	if m.state.Phase != "normal" && m.state.Phase != "boss" {
+		return m, nil
+	}
+
+	modifiers := m.activeModifiers()
+	drain := 1
+	if hasModifier(modifiers, ModifierShrinkingTime) {
+		drain = shrinkingDrain(int(time.Since(m.state.ModifierStart).Seconds()))
+	}
+	if hasModifier(modifiers, ModifierMemory) && !m.sess.MaskPending &&
+		time.Since(m.state.ModifierStart) >= time.Duration(memoryRevealSeconds(m.sess.GetText()))*time.Second {
+		m.sess.MaskPending = true
+	}
+
+	m.state.TimeLeft -= drain
 	m.sess.RemainingTimeDisplay = m.state.TimeLeft
+	if m.state.Phase == "boss" && m.state.TimeLeft <= 0 {
+		m.foldChunk()
+		m.finishHiddenBossRound(false)
+		return m, m.tickTimer()
+	}
 	if m.state.TimeLeft <= 0 {
+		m.foldChunk()
 		level := m.state.Levels[m.state.CurrentLevel]
 		if level.BossRound != nil {
 			result := BossResult{
@@ -371,14 +650,11 @@ func (m *GameModel) handleTick() (tea.Model, tea.Cmd) {
 				Completed: false,
 			}
 			m.state.BossResults = append(m.state.BossResults, result)
-			m.state.Phase = "complete"
+		}
+		if m.checkLevelRequirements(level) {
+			m.completeLevel()
 		} else {
-
-			if m.checkLevelRequirements(level) {
-				m.state.Phase = "complete"
-			} else {
-				m.state.Phase = "failed"
-			}
+			m.failLevel()
 		}
 		return m, nil
 	}
@@ -387,18 +663,76 @@ func (m *GameModel) handleTick() (tea.Model, tea.Cmd) {
 
 func (m *GameModel) generateNextChunk() {
 	level := m.state.Levels[m.state.CurrentLevel]
-	chunkText := internal.GenerateWordsDynamic(level.ChunkSize, m.config.Language.Default)
+	chunkText := m.generateText(level, level.ChunkSize)
 	m.sess.SetText(chunkText)
 	m.sess.ExternalMistakes = m.state.Mistakes
+	m.applySessionModifiers()
 	m.sess.Start()
 }
 
-func (m *GameModel) advanceLevel() (tea.Model, tea.Cmd) {
-	UpdateProgress(m.config, m.state.CurrentLevel)
+// activeModifiers returns the modifiers of the boss currently being played.
+func (m GameModel) activeModifiers() []string {
+	if m.state.Phase == "boss" && m.state.ActiveBoss != nil {
+		return m.state.ActiveBoss.Modifiers
+	}
+	level := m.state.Levels[m.state.CurrentLevel]
+	if level.BossRound != nil {
+		return level.BossRound.Modifiers
+	}
+	return nil
+}
 
+func (m *GameModel) applySessionModifiers() {
+	modifiers := m.activeModifiers()
+	m.sess.HideErrors = hasModifier(modifiers, ModifierHiddenErrors)
+	m.sess.NoBackspace = hasModifier(modifiers, ModifierNoBackspace)
+	m.sess.MaskPending = false
+	m.state.ModifierStart = time.Now()
+}
+
+// completeLevel ends the current level and records its score on the map.
+func (m *GameModel) completeLevel() {
+	m.state.Phase = "complete"
+	m.saveLevelRecord()
+	if m.adaptive != nil {
+		m.adaptive.Record(true, m.calculateWPM(), m.calculateAccuracy())
+		return
+	}
+
+	level := m.state.Levels[m.state.CurrentLevel]
+	hasBoss := level.BossRound != nil || len(level.BossRounds) > 0
+	bossCleared := true
+	for _, result := range m.levelBossResults() {
+		bossCleared = bossCleared && result.Completed
+	}
+
+	accuracy := m.calculateAccuracy()
+	stars := StarRating(level, accuracy, bossCleared)
+	if err := RecordLevelResult(m.config, m.pack, m.state.CurrentLevel, m.calculateWPM(), accuracy, stars, hasBoss && bossCleared); err != nil {
+		m.saveErr = fmt.Errorf("level progress: %w", err)
+	}
+	if progress, err := LoadProgress(m.config, m.pack); err == nil {
+		m.progress = progress
+	}
+}
+
+func (m *GameModel) failLevel() {
+	m.state.Phase = "failed"
+	if m.adaptive != nil {
+		m.adaptive.Record(false, m.calculateWPM(), m.calculateAccuracy())
+	}
+}
+
+// saveLevelRecord adds a passed level to history. It is saved as soon as
+// the level is complete, so the result screen can show a failed save.
+func (m *GameModel) saveLevelRecord() {
+	level := m.state.Levels[m.state.CurrentLevel]
+	language, source := m.levelText(level)
 	record := &session.SessionRecord{
 		Mode:       "challenge",
-		Tier:       fmt.Sprintf("lv%d", m.state.CurrentLevel+1),
+		Tier:       level.Difficulty,
+		Language:   language,
+		TextSource: source,
 		TextLength: len(m.sess.GetText()),
 		DurationMs: time.Since(m.state.StartTime).Milliseconds(),
 		WPM:        m.calculateWPM(),
@@ -406,7 +740,17 @@ func (m *GameModel) advanceLevel() (tea.Model, tea.Cmd) {
 		Accuracy:   m.calculateAccuracy(),
 		Mistakes:   m.state.Mistakes,
 	}
-	session.SaveSessionRecord(m.config, record)
+	if err := session.SaveSessionRecord(m.config, record); err != nil {
+		m.saveErr = fmt.Errorf("history: %w", err)
+	}
+}
+
+func (m *GameModel) advanceLevel() (tea.Model, tea.Cmd) {
+	m.state.LevelBossStart = len(m.state.BossResults)
+
+	if m.adaptive != nil {
+		m.state.Levels = append(m.state.Levels, m.adaptive.NextLevel(len(m.state.Levels)+1))
+	}
 
 	m.state.CurrentLevel++
 	if m.state.CurrentLevel >= len(m.state.Levels) {
@@ -416,7 +760,7 @@ func (m *GameModel) advanceLevel() (tea.Model, tea.Cmd) {
 	nextLevel := m.state.Levels[m.state.CurrentLevel]
 	m.resetLevelState(nextLevel)
 
-	return m, nil
+	return m, m.tickTimer()
 }
 
 func (m GameModel) calculateWPM() float64 {
@@ -424,6 +768,11 @@ func (m GameModel) calculateWPM() float64 {
 	return session.CalculateWPM(m.state.TotalChars, levelDuration)
 }
 
+func (m GameModel) calculateNetWPM() float64 {
+	levelDuration := time.Since(m.state.LevelStartTime)
+	return session.CalculateNetWPM(m.state.TotalChars, m.state.Mistakes, levelDuration)
+}
+
 func (m GameModel) calculateAccuracy() float64 {
 	return session.CalculateAccuracy(m.state.TotalChars, m.state.Mistakes)
 }
@@ -438,24 +787,138 @@ func (m GameModel) countCompletedBosses() int {
 	return count
 }
 
+// levelText is the language and text source a level's words come from, as
+// recorded in history. Text from a file has no known language.
+func (m *GameModel) levelText(level Level) (string, string) {
+	if level.TextSource != "" && level.TextSource != "words" {
+		return "", "file:" + filepath.Base(strings.TrimPrefix(level.TextSource, "file:"))
+	}
+	if level.Language != "" {
+		return level.Language, "words"
+	}
+	return m.config.Language.Default, "words"
+}
+
+func (m *GameModel) generateText(level Level, words int) string {
+	if level.TextSource != "" && level.TextSource != "words" {
+		if text := wordsFromSource(level.TextSource, words); text != "" {
+			return text
+		}
+	}
+
+	language := level.Language
+	if language == "" {
+		language = m.config.Language.Default
+	}
+	return internal.GenerateWordsDynamic(words, language)
+}
+
+func (m GameModel) levelBossResults() []BossResult {
+	if m.state.LevelBossStart > len(m.state.BossResults) {
+		return nil
+	}
+	return m.state.BossResults[m.state.LevelBossStart:]
+}
+
+// pendingHiddenBoss returns the hidden boss waiting at the current chunk, if
+// it has not already been fought during this attempt.
+func (m GameModel) pendingHiddenBoss(level Level) *BossRound {
+	for i := range level.BossRounds {
+		boss := &level.BossRounds[i]
+		if boss.TriggerChunk == m.state.ChunkIndex && !m.state.BossesTriggered[boss.TriggerChunk] {
+			return boss
+		}
+	}
+	return nil
+}
+
 func (m *GameModel) startHiddenBossRound(boss BossRound) {
-	bossText := internal.GenerateWordsDynamic(boss.Words, m.config.Language.Default)
-	m.sess.SetText(bossText)
+	bossText := m.generateText(m.state.Levels[m.state.CurrentLevel], boss.Words)
+	m.sess.SetText(applyTextModifiers(bossText, boss.Modifiers))
+	m.sess.ExternalMistakes = m.state.Mistakes
+	m.state.BossesTriggered[boss.TriggerChunk] = true
+	m.state.ActiveBoss = &boss
+	m.state.PausedTimeLeft = m.state.TimeLeft
+	m.state.BossStartTime = time.Now()
+	m.state.BossStartChars = m.state.TotalChars
+	m.state.BossStartMistakes = m.state.Mistakes
 	m.state.Phase = "boss"
 	m.state.TimeLeft = boss.TimeLimit
+	m.sess.RemainingTimeDisplay = m.state.TimeLeft
+	m.applySessionModifiers()
 	m.sess.Start()
 }
 
+// finishHiddenBossRound records the boss result and hands the clock back to
+// the level, which was paused while the boss was active.
+func (m *GameModel) finishHiddenBossRound(completed bool) {
+	boss := m.state.ActiveBoss
+	if boss == nil {
+		return
+	}
+
+	chars := m.state.TotalChars - m.state.BossStartChars
+	mistakes := m.state.Mistakes - m.state.BossStartMistakes
+	duration := time.Since(m.state.BossStartTime)
+
+	result := BossResult{
+		Name:       boss.Name,
+		WPM:        session.CalculateWPM(chars, duration),
+		Accuracy:   session.CalculateAccuracy(chars, mistakes),
+		Mistakes:   mistakes,
+		Chars:      chars,
+		DurationMs: duration.Milliseconds(),
+		Hidden:     true,
+		Completed:  completed,
+	}
+	m.state.BossResults = append(m.state.BossResults, result)
+	m.saveBossRecord(result)
+
+	m.state.ActiveBoss = nil
+	m.state.Phase = "normal"
+	m.state.TimeLeft = m.state.PausedTimeLeft
+	m.sess.RemainingTimeDisplay = m.state.TimeLeft
+	m.generateNextChunk()
+}
+
+// saveBossRecord writes a hidden boss attempt to history, whether it was
+// beaten or not.
+func (m *GameModel) saveBossRecord(result BossResult) {
+	level := m.state.Levels[m.state.CurrentLevel]
+	language, source := m.levelText(level)
+	record := &session.SessionRecord{
+		Mode:       "challenge-boss",
+		Tier:       level.Difficulty,
+		Language:   language,
+		TextSource: source,
+		TextLength: result.Chars,
+		DurationMs: result.DurationMs,
+		WPM:        result.WPM,
+		CPM:        result.WPM * 5,
+		Accuracy:   result.Accuracy,
+		Mistakes:   result.Mistakes,
+	}
+	if err := session.SaveSessionRecord(m.config, record); err != nil {
+		m.saveErr = fmt.Errorf("history: %w", err)
+	}
+}
+
 func (m GameModel) tickTimer() tea.Cmd {
+	id := m.state.TickID
 	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		return TickMsg{}
+		return TickMsg{ID: id}
 	})
 }
 
-type TickMsg struct{}
+// TickMsg carries the timer generation so ticks from a previous attempt are
+// ignored after a retry or level change.
+type TickMsg struct {
+	ID int
+}
 
 type LevelRequirements struct {
 	MinWPM      float64
+	MinNetWPM   float64
 	MinAccuracy float64
 	MaxMistakes int
 	MinWords    int
@@ -465,7 +928,9 @@ func (m GameModel) checkLevelRequirements(level Level) bool {
 	accuracy := m.calculateAccuracy()
 	mistakes := m.state.Mistakes
 
-	return accuracy >= level.MinAccuracy &&
+	return m.calculateWPM() >= level.MinWPM &&
+		m.calculateNetWPM() >= level.MinNetWPM &&
+		accuracy >= level.MinAccuracy &&
 		mistakes <= level.MaxMistakes &&
 		m.state.TotalChars >= level.MinChars &&
 		m.state.WordsTyped >= level.MinWords
@@ -484,7 +949,8 @@ func (m GameModel) renderLevelDialog(content string, borderColor string) string
 
 func (m GameModel) getLevelRequirements(level Level) LevelRequirements {
 	return LevelRequirements{
-		MinWPM:      0,
+		MinWPM:      level.MinWPM,
+		MinNetWPM:   level.MinNetWPM,
 		MinAccuracy: level.MinAccuracy,
 		MaxMistakes: level.MaxMistakes,
 		MinWords:    level.MinWords,
@@ -492,8 +958,16 @@ func (m GameModel) getLevelRequirements(level Level) LevelRequirements {
 }
 
 func (m *GameModel) resetLevelState(level Level) {
+	m.state.TickID++
+	m.saveErr = nil
 	m.state.Phase = "normal"
 	m.state.ChunkIndex = 0
+	m.state.ActiveBoss = nil
+	m.state.BossesTriggered = make(map[int]bool)
+	if m.state.LevelBossStart > len(m.state.BossResults) {
+		m.state.LevelBossStart = len(m.state.BossResults)
+	}
+	m.state.BossResults = m.state.BossResults[:m.state.LevelBossStart]
 	m.state.TimeLeft = level.Time
 	m.state.LevelStartTime = time.Now()
 	m.state.WordsTyped = 0
@@ -502,25 +976,173 @@ func (m *GameModel) resetLevelState(level Level) {
 
 	var text string
 	if level.BossRound != nil {
-		text = internal.GenerateWordsDynamic(level.BossRound.Words, m.config.Language.Default)
+		text = applyTextModifiers(m.generateText(level, level.BossRound.Words), level.BossRound.Modifiers)
 	} else {
-		text = internal.GenerateWordsDynamic(level.ChunkSize, m.config.Language.Default)
+		text = m.generateText(level, level.ChunkSize)
 	}
 	m.sess.SetText(text)
 	m.sess.ExternalMistakes = m.state.Mistakes
 	m.sess.SetTier(fmt.Sprintf("lv%d", m.state.CurrentLevel+1))
+	m.applySessionModifiers()
 	m.sess.Start()
+
+	if level.BossRound != nil || len(level.BossRounds) > 0 {
+		m.state.Phase = "intro"
+	}
+}
+
+// startLevel leaves the intro screen and starts the clock.
+func (m *GameModel) startLevel() (tea.Model, tea.Cmd) {
+	m.state.TickID++
+	m.state.Phase = "normal"
+	m.state.LevelStartTime = time.Now()
+	m.state.ModifierStart = m.state.LevelStartTime
+	m.sess.Start()
+	return m, m.tickTimer()
+}
+
+func (m *GameModel) openLevelMap() (tea.Model, tea.Cmd) {
+	m.state.TickID++
+	m.state.Phase = "map"
+	m.mapCursor = m.state.CurrentLevel
+	return m, nil
+}
+
+func (m *GameModel) handleMapKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := len(m.state.Levels) - 1
+	switch key.String() {
+	case "up", "k":
+		m.mapCursor--
+	case "down", "j":
+		m.mapCursor++
+	case "pgup":
+		m.mapCursor -= 10
+	case "pgdown":
+		m.mapCursor += 10
+	case "home", "g":
+		m.mapCursor = 0
+	case "end", "G":
+		m.mapCursor = last
+	case "enter", " ":
+		if !m.progress.IsUnlocked(m.mapCursor) {
+			return m, nil
+		}
+		m.state.CurrentLevel = m.mapCursor
+		return m.retryLevel()
+	case "q", "esc":
+		return m, tea.Quit
+	}
+
+	if m.mapCursor < 0 {
+		m.mapCursor = 0
+	}
+	if m.mapCursor > last {
+		m.mapCursor = last
+	}
+	return m, nil
+}
+
+func (m GameModel) viewLevelMap() string {
+	bosses, cleared := 0, 0
+	for i, level := range m.state.Levels {
+		if level.BossRound == nil && len(level.BossRounds) == 0 {
+			continue
+		}
+		bosses++
+		if m.progress.Levels[i].BossCleared {
+			cleared++
+		}
+	}
+
+	var b strings.Builder
+	title := "Challenge Map"
+	if m.pack != "" {
+		title += " - " + m.pack
+	}
+	b.WriteString(title + "\n")
+	b.WriteString(fmt.Sprintf("Stars: %d/%d   Bosses: %d/%d\n\n",
+		m.progress.TotalStars(), len(m.state.Levels)*3, cleared, bosses))
+
+	rows := m.height - 12
+	if rows < 3 {
+		rows = 3
+	}
+	start := m.mapCursor - rows/2
+	if start > len(m.state.Levels)-rows {
+		start = len(m.state.Levels) - rows
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + rows
+	if end > len(m.state.Levels) {
+		end = len(m.state.Levels)
+	}
+
+	for i := start; i < end; i++ {
+		level := m.state.Levels[i]
+		cursor := "  "
+		if i == m.mapCursor {
+			cursor = "▶ "
+		}
+
+		name := level.Name
+		if len(name) > 32 {
+			name = name[:32]
+		}
+
+		var line string
+		if !m.progress.IsUnlocked(i) {
+			line = fmt.Sprintf("%s%3d  %-32s  locked", cursor, i+1, name)
+		} else if score, ok := m.progress.Levels[i]; ok {
+			line = fmt.Sprintf("%s%3d  %-32s  %s  %5.1f WPM  %5.1f%%", cursor, i+1, name, renderStars(score.Stars), score.BestWPM, score.BestAccuracy)
+			if score.BossCleared {
+				line += "  ⚔"
+			}
+		} else {
+			line = fmt.Sprintf("%s%3d  %-32s  %s", cursor, i+1, name, renderStars(0))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n↑/↓ select  Enter play  Q quit")
+	b.WriteString(m.saveErrNote())
+
+	content := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(b.String())
+
+	return m.renderLevelDialog(content, m.config.Theme.Colors.TextPrimary)
+}
+
+func renderStars(stars int) string {
+	return strings.Repeat("★", stars) + strings.Repeat("☆", 3-stars)
 }
 
 func (m *GameModel) retryLevel() (tea.Model, tea.Cmd) {
+	if m.adaptive != nil {
+		m.state.Levels[m.state.CurrentLevel] = m.adaptive.NextLevel(m.state.CurrentLevel + 1)
+	}
 	level := m.state.Levels[m.state.CurrentLevel]
 	m.resetLevelState(level)
-	return m, nil
+	return m, m.tickTimer()
+}
+
+func StartChallengeGame(levels []Level, pack string) error {
+	if len(levels) == 0 {
+		return fmt.Errorf("no challenge levels to play")
+	}
+	cfg := config.GetConfig()
+	model := NewGameModel(cfg, levels, pack)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
 }
 
-func StartChallengeGame(levels []Level) error {
+func StartAdaptiveChallenge() error {
 	cfg := config.GetConfig()
-	model := NewGameModel(cfg, levels)
+	model := NewAdaptiveGameModel(cfg)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
@@ -1,5 +1,11 @@
 package challenge
 
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
 type ChallengeLevel struct {
 	Name        string  `toml:"name"`
 	TimeSeconds int     `toml:"time_seconds"`
@@ -7,114 +13,192 @@ type ChallengeLevel struct {
 	MaxMistakes int     `toml:"max_mistakes"`
 	MinChars    int     `toml:"min_chars"`
 	MinWords    int     `toml:"min_words"`
+	MinWPM      float64 `toml:"min_wpm"`
+	MinNetWPM   float64 `toml:"min_net_wpm"`
 	IsBoss      bool    `toml:"is_boss"`
+	Language    string  `toml:"language"`
+	TextSource  string  `toml:"text_source"`
+
+	Modifiers    []string     `toml:"modifiers"`
+	HiddenBosses []HiddenBoss `toml:"hidden_bosses"`
+}
+
+// HiddenBoss is a surprise round that interrupts a level once the player
+// reaches TriggerChunk. The level clock is paused while it runs.
+type HiddenBoss struct {
+	Name         string   `toml:"name"`
+	TriggerChunk int      `toml:"trigger_chunk"`
+	Words        int      `toml:"words"`
+	TimeSeconds  int      `toml:"time_seconds"`
+	Modifiers    []string `toml:"modifiers"`
 }
 
 func GetBuiltInLevels() []ChallengeLevel {
+	return withPaceMinimums(withBossModifiers(withHiddenBosses(builtInLevels())))
+}
+
+// withPaceMinimums sets each level's character and word minimums to what
+// typing at its MinWPM for the whole time limit produces, so the speed and
+// volume requirements always agree. Under shrinking time the limit runs out
+// early, so only the real seconds it lasts count.
+func withPaceMinimums(levels []ChallengeLevel) []ChallengeLevel {
+	for i := range levels {
+		seconds := levels[i].TimeSeconds
+		if hasModifier(levels[i].Modifiers, ModifierShrinkingTime) {
+			seconds = shrinkingSeconds(seconds)
+		}
+		chars := int(math.Ceil(levels[i].MinWPM * 5 * float64(seconds) / 60))
+		levels[i].MinChars = chars
+		levels[i].MinWords = chars / 5
+	}
+	return levels
+}
+
+// withBossModifiers makes the built-in boss levels harder with each tier.
+func withBossModifiers(levels []ChallengeLevel) []ChallengeLevel {
+	tiers := []struct {
+		prefix    string
+		modifiers []string
+	}{
+		{"Medium Boss", []string{ModifierNoBackspace}},
+		{"Hard Boss", []string{ModifierRandomCaps, ModifierNoBackspace}},
+		{"Expert Boss", []string{ModifierReversed, ModifierHiddenErrors}},
+		{"Level 100", []string{ModifierHiddenErrors, ModifierNoBackspace, ModifierShrinkingTime}},
+	}
+
+	for i := range levels {
+		for _, tier := range tiers {
+			if levels[i].IsBoss && strings.HasPrefix(levels[i].Name, tier.prefix) {
+				levels[i].Modifiers = tier.modifiers
+			}
+		}
+	}
+	return levels
+}
+
+// withHiddenBosses hides a boss in the third chunk of every fifth regular
+// level, growing with the level it interrupts.
+func withHiddenBosses(levels []ChallengeLevel) []ChallengeLevel {
+	for i := range levels {
+		if levels[i].IsBoss || i%5 != 2 {
+			continue
+		}
+		words := 10 + i/5
+		levels[i].HiddenBosses = []HiddenBoss{{
+			Name:         fmt.Sprintf("Ambush %d", i/5+1),
+			TriggerChunk: 2,
+			Words:        words,
+			TimeSeconds:  words * 60 / 40,
+		}}
+	}
+	return levels
+}
+
+func builtInLevels() []ChallengeLevel {
 	return []ChallengeLevel{
-		{Name: "Level 1 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.0, MaxMistakes: 98, MinChars: 10, MinWords: 2, IsBoss: false},
-		{Name: "Level 2 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.2, MaxMistakes: 96, MinChars: 20, MinWords: 4, IsBoss: false},
-		{Name: "Level 3 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.4, MaxMistakes: 94, MinChars: 30, MinWords: 6, IsBoss: false},
-		{Name: "Level 4 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.6, MaxMistakes: 92, MinChars: 40, MinWords: 8, IsBoss: false},
-		{Name: "Level 5 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.8, MaxMistakes: 90, MinChars: 40, MinWords: 10, IsBoss: false},
-		{Name: "Level 6 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.0, MaxMistakes: 88, MinChars: 60, MinWords: 12, IsBoss: false},
-		{Name: "Level 7 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.2, MaxMistakes: 86, MinChars: 70, MinWords: 14, IsBoss: false},
-		{Name: "Level 8 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.4, MaxMistakes: 84, MinChars: 80, MinWords: 16, IsBoss: false},
-		{Name: "Level 9 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.6, MaxMistakes: 82, MinChars: 90, MinWords: 18, IsBoss: false},
-		{Name: "Easy Boss 2 - Speed Challenge", TimeSeconds: 31, MinAccuracy: 93.8, MaxMistakes: 80, MinChars: 100, MinWords: 20, IsBoss: true},
-		{Name: "Level 11 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.0, MaxMistakes: 78, MinChars: 110, MinWords: 22, IsBoss: false},
-		{Name: "Level 12 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.2, MaxMistakes: 76, MinChars: 120, MinWords: 24, IsBoss: false},
-		{Name: "Level 13 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.4, MaxMistakes: 74, MinChars: 130, MinWords: 26, IsBoss: false},
-		{Name: "Level 14 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.6, MaxMistakes: 72, MinChars: 140, MinWords: 28, IsBoss: false},
-		{Name: "Easy Boss 3 - Speed Challenge", TimeSeconds: 32, MinAccuracy: 94.8, MaxMistakes: 70, MinChars: 150, MinWords: 30, IsBoss: true},
-		{Name: "Level 16 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.0, MaxMistakes: 68, MinChars: 160, MinWords: 32, IsBoss: false},
-		{Name: "Level 17 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.2, MaxMistakes: 66, MinChars: 170, MinWords: 34, IsBoss: false},
-		{Name: "Level 18 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.4, MaxMistakes: 64, MinChars: 180, MinWords: 36, IsBoss: false},
-		{Name: "Level 19 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.6, MaxMistakes: 62, MinChars: 190, MinWords: 38, IsBoss: false},
-		{Name: "Easy Boss 4 - Speed Challenge", TimeSeconds: 33, MinAccuracy: 95.8, MaxMistakes: 60, MinChars: 200, MinWords: 40, IsBoss: true},
+		{Name: "Level 1 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.0, MaxMistakes: 98, MinWPM: 15, IsBoss: false},
+		{Name: "Level 2 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.2, MaxMistakes: 96, MinWPM: 15.5, IsBoss: false},
+		{Name: "Level 3 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.4, MaxMistakes: 94, MinWPM: 16, IsBoss: false},
+		{Name: "Level 4 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.6, MaxMistakes: 92, MinWPM: 16.5, IsBoss: false},
+		{Name: "Level 5 - Easy Warmup", TimeSeconds: 30, MinAccuracy: 92.8, MaxMistakes: 90, MinWPM: 17, IsBoss: false},
+		{Name: "Level 6 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.0, MaxMistakes: 88, MinWPM: 17.5, IsBoss: false},
+		{Name: "Level 7 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.2, MaxMistakes: 86, MinWPM: 18, IsBoss: false},
+		{Name: "Level 8 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.4, MaxMistakes: 84, MinWPM: 18.5, IsBoss: false},
+		{Name: "Level 9 - Easy Warmup", TimeSeconds: 31, MinAccuracy: 93.6, MaxMistakes: 82, MinWPM: 19, IsBoss: false},
+		{Name: "Easy Boss 2 - Speed Challenge", TimeSeconds: 31, MinAccuracy: 93.8, MaxMistakes: 80, MinWPM: 19.5, IsBoss: true},
+		{Name: "Level 11 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.0, MaxMistakes: 78, MinWPM: 20.5, IsBoss: false},
+		{Name: "Level 12 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.2, MaxMistakes: 76, MinWPM: 21, IsBoss: false},
+		{Name: "Level 13 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.4, MaxMistakes: 74, MinWPM: 21.5, IsBoss: false},
+		{Name: "Level 14 - Easy Warmup", TimeSeconds: 32, MinAccuracy: 94.6, MaxMistakes: 72, MinWPM: 22, IsBoss: false},
+		{Name: "Easy Boss 3 - Speed Challenge", TimeSeconds: 32, MinAccuracy: 94.8, MaxMistakes: 70, MinWPM: 22.5, IsBoss: true},
+		{Name: "Level 16 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.0, MaxMistakes: 68, MinWPM: 23, IsBoss: false},
+		{Name: "Level 17 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.2, MaxMistakes: 66, MinWPM: 23.5, IsBoss: false},
+		{Name: "Level 18 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.4, MaxMistakes: 64, MinWPM: 24, IsBoss: false},
+		{Name: "Level 19 - Easy Warmup", TimeSeconds: 33, MinAccuracy: 95.6, MaxMistakes: 62, MinWPM: 24.5, IsBoss: false},
+		{Name: "Easy Boss 4 - Speed Challenge", TimeSeconds: 33, MinAccuracy: 95.8, MaxMistakes: 60, MinWPM: 25, IsBoss: true},
 
-		{Name: "Level 21 - Medium Challenge", TimeSeconds: 35, MinAccuracy: 95.0, MaxMistakes: 29, MinChars: 215, MinWords: 43, IsBoss: false},
-		{Name: "Level 22 - Medium Challenge", TimeSeconds: 35, MinAccuracy: 95.1, MaxMistakes: 28, MinChars: 230, MinWords: 46, IsBoss: false},
-		{Name: "Level 23 - Medium Challenge", TimeSeconds: 36, MinAccuracy: 95.2, MaxMistakes: 27, MinChars: 245, MinWords: 49, IsBoss: false},
-		{Name: "Level 24 - Medium Challenge", TimeSeconds: 36, MinAccuracy: 95.3, MaxMistakes: 26, MinChars: 260, MinWords: 52, IsBoss: false},
-		{Name: "Medium Boss 1 - Accuracy Master", TimeSeconds: 36, MinAccuracy: 95.4, MaxMistakes: 25, MinChars: 275, MinWords: 55, IsBoss: true},
-		{Name: "Level 26 - Medium Challenge", TimeSeconds: 37, MinAccuracy: 95.5, MaxMistakes: 24, MinChars: 290, MinWords: 58, IsBoss: false},
-		{Name: "Level 27 - Medium Challenge", TimeSeconds: 37, MinAccuracy: 95.6, MaxMistakes: 23, MinChars: 305, MinWords: 61, IsBoss: false},
-		{Name: "Level 28 - Medium Challenge", TimeSeconds: 37, MinAccuracy: 95.7, MaxMistakes: 22, MinChars: 320, MinWords: 64, IsBoss: false},
-		{Name: "Level 29 - Medium Challenge", TimeSeconds: 38, MinAccuracy: 95.8, MaxMistakes: 21, MinChars: 335, MinWords: 67, IsBoss: false},
-		{Name: "Medium Boss 2 - Accuracy Master", TimeSeconds: 38, MinAccuracy: 95.9, MaxMistakes: 20, MinChars: 350, MinWords: 70, IsBoss: true},
-		{Name: "Level 31 - Medium Challenge", TimeSeconds: 38, MinAccuracy: 96.0, MaxMistakes: 19, MinChars: 365, MinWords: 73, IsBoss: false},
-		{Name: "Level 32 - Medium Challenge", TimeSeconds: 39, MinAccuracy: 96.1, MaxMistakes: 18, MinChars: 380, MinWords: 76, IsBoss: false},
-		{Name: "Level 33 - Medium Challenge", TimeSeconds: 39, MinAccuracy: 96.2, MaxMistakes: 17, MinChars: 395, MinWords: 79, IsBoss: false},
-		{Name: "Level 34 - Medium Challenge", TimeSeconds: 39, MinAccuracy: 96.3, MaxMistakes: 16, MinChars: 410, MinWords: 82, IsBoss: false},
-		{Name: "Medium Boss 3 - Accuracy Master", TimeSeconds: 40, MinAccuracy: 96.4, MaxMistakes: 15, MinChars: 425, MinWords: 85, IsBoss: true},
-		{Name: "Level 36 - Medium Challenge", TimeSeconds: 40, MinAccuracy: 96.5, MaxMistakes: 14, MinChars: 440, MinWords: 88, IsBoss: false},
-		{Name: "Level 37 - Medium Challenge", TimeSeconds: 40, MinAccuracy: 96.6, MaxMistakes: 13, MinChars: 455, MinWords: 91, IsBoss: false},
-		{Name: "Level 38 - Medium Challenge", TimeSeconds: 41, MinAccuracy: 96.7, MaxMistakes: 12, MinChars: 470, MinWords: 94, IsBoss: false},
-		{Name: "Level 39 - Medium Challenge", TimeSeconds: 41, MinAccuracy: 96.8, MaxMistakes: 11, MinChars: 485, MinWords: 97, IsBoss: false},
-		{Name: "Medium Boss 4 - Accuracy Master", TimeSeconds: 41, MinAccuracy: 96.9, MaxMistakes: 10, MinChars: 500, MinWords: 100, IsBoss: true},
-		{Name: "Level 41 - Medium Challenge", TimeSeconds: 42, MinAccuracy: 97.0, MaxMistakes: 9, MinChars: 515, MinWords: 103, IsBoss: false},
-		{Name: "Level 42 - Medium Challenge", TimeSeconds: 42, MinAccuracy: 97.1, MaxMistakes: 8, MinChars: 530, MinWords: 106, IsBoss: false},
-		{Name: "Level 43 - Medium Challenge", TimeSeconds: 42, MinAccuracy: 97.2, MaxMistakes: 7, MinChars: 545, MinWords: 109, IsBoss: false},
-		{Name: "Level 44 - Medium Challenge", TimeSeconds: 43, MinAccuracy: 97.3, MaxMistakes: 6, MinChars: 560, MinWords: 112, IsBoss: false},
-		{Name: "Medium Boss 5 - Accuracy Master", TimeSeconds: 43, MinAccuracy: 97.4, MaxMistakes: 5, MinChars: 575, MinWords: 115, IsBoss: true},
-		{Name: "Level 46 - Medium Challenge", TimeSeconds: 43, MinAccuracy: 97.5, MaxMistakes: 4, MinChars: 590, MinWords: 118, IsBoss: false},
-		{Name: "Level 47 - Medium Challenge", TimeSeconds: 44, MinAccuracy: 97.6, MaxMistakes: 3, MinChars: 605, MinWords: 121, IsBoss: false},
-		{Name: "Level 48 - Medium Challenge", TimeSeconds: 44, MinAccuracy: 97.7, MaxMistakes: 2, MinChars: 620, MinWords: 124, IsBoss: false},
-		{Name: "Level 49 - Medium Challenge", TimeSeconds: 44, MinAccuracy: 97.8, MaxMistakes: 1, MinChars: 635, MinWords: 127, IsBoss: false},
-		{Name: "Medium Boss 6 - Accuracy Master", TimeSeconds: 45, MinAccuracy: 97.9, MaxMistakes: 1, MinChars: 650, MinWords: 130, IsBoss: true},
+		{Name: "Level 21 - Medium Challenge", TimeSeconds: 35, MinAccuracy: 95.0, MaxMistakes: 29, MinWPM: 25, IsBoss: false},
+		{Name: "Level 22 - Medium Challenge", TimeSeconds: 35, MinAccuracy: 95.1, MaxMistakes: 28, MinWPM: 25.5, IsBoss: false},
+		{Name: "Level 23 - Medium Challenge", TimeSeconds: 36, MinAccuracy: 95.2, MaxMistakes: 27, MinWPM: 26, IsBoss: false},
+		{Name: "Level 24 - Medium Challenge", TimeSeconds: 36, MinAccuracy: 95.3, MaxMistakes: 26, MinWPM: 26.5, IsBoss: false},
+		{Name: "Medium Boss 1 - Accuracy Master", TimeSeconds: 36, MinAccuracy: 95.4, MaxMistakes: 25, MinWPM: 27, IsBoss: true},
+		{Name: "Level 26 - Medium Challenge", TimeSeconds: 37, MinAccuracy: 95.5, MaxMistakes: 24, MinWPM: 27.5, IsBoss: false},
+		{Name: "Level 27 - Medium Challenge", TimeSeconds: 37, MinAccuracy: 95.6, MaxMistakes: 23, MinWPM: 28, IsBoss: false},
+		{Name: "Level 28 - Medium Challenge", TimeSeconds: 37, MinAccuracy: 95.7, MaxMistakes: 22, MinWPM: 28.5, IsBoss: false},
+		{Name: "Level 29 - Medium Challenge", TimeSeconds: 38, MinAccuracy: 95.8, MaxMistakes: 21, MinWPM: 29, IsBoss: false},
+		{Name: "Medium Boss 2 - Accuracy Master", TimeSeconds: 38, MinAccuracy: 95.9, MaxMistakes: 20, MinWPM: 29.5, IsBoss: true},
+		{Name: "Level 31 - Medium Challenge", TimeSeconds: 38, MinAccuracy: 96.0, MaxMistakes: 19, MinWPM: 30, IsBoss: false},
+		{Name: "Level 32 - Medium Challenge", TimeSeconds: 39, MinAccuracy: 96.1, MaxMistakes: 18, MinWPM: 30.5, IsBoss: false},
+		{Name: "Level 33 - Medium Challenge", TimeSeconds: 39, MinAccuracy: 96.2, MaxMistakes: 17, MinWPM: 31, IsBoss: false},
+		{Name: "Level 34 - Medium Challenge", TimeSeconds: 39, MinAccuracy: 96.3, MaxMistakes: 16, MinWPM: 31.5, IsBoss: false},
+		{Name: "Medium Boss 3 - Accuracy Master", TimeSeconds: 40, MinAccuracy: 96.4, MaxMistakes: 15, MinWPM: 32, IsBoss: true},
+		{Name: "Level 36 - Medium Challenge", TimeSeconds: 40, MinAccuracy: 96.5, MaxMistakes: 14, MinWPM: 33, IsBoss: false},
+		{Name: "Level 37 - Medium Challenge", TimeSeconds: 40, MinAccuracy: 96.6, MaxMistakes: 13, MinWPM: 33.5, IsBoss: false},
+		{Name: "Level 38 - Medium Challenge", TimeSeconds: 41, MinAccuracy: 96.7, MaxMistakes: 12, MinWPM: 34, IsBoss: false},
+		{Name: "Level 39 - Medium Challenge", TimeSeconds: 41, MinAccuracy: 96.8, MaxMistakes: 11, MinWPM: 34.5, IsBoss: false},
+		{Name: "Medium Boss 4 - Accuracy Master", TimeSeconds: 41, MinAccuracy: 96.9, MaxMistakes: 10, MinWPM: 35, IsBoss: true},
+		{Name: "Level 41 - Medium Challenge", TimeSeconds: 42, MinAccuracy: 97.0, MaxMistakes: 9, MinWPM: 35.5, IsBoss: false},
+		{Name: "Level 42 - Medium Challenge", TimeSeconds: 42, MinAccuracy: 97.1, MaxMistakes: 8, MinWPM: 36, IsBoss: false},
+		{Name: "Level 43 - Medium Challenge", TimeSeconds: 42, MinAccuracy: 97.2, MaxMistakes: 7, MinWPM: 36.5, IsBoss: false},
+		{Name: "Level 44 - Medium Challenge", TimeSeconds: 43, MinAccuracy: 97.3, MaxMistakes: 6, MinWPM: 37, IsBoss: false},
+		{Name: "Medium Boss 5 - Accuracy Master", TimeSeconds: 43, MinAccuracy: 97.4, MaxMistakes: 5, MinWPM: 37.5, IsBoss: true},
+		{Name: "Level 46 - Medium Challenge", TimeSeconds: 43, MinAccuracy: 97.5, MaxMistakes: 4, MinWPM: 38, IsBoss: false},
+		{Name: "Level 47 - Medium Challenge", TimeSeconds: 44, MinAccuracy: 97.6, MaxMistakes: 3, MinWPM: 38.5, IsBoss: false},
+		{Name: "Level 48 - Medium Challenge", TimeSeconds: 44, MinAccuracy: 97.7, MaxMistakes: 2, MinWPM: 39, IsBoss: false},
+		{Name: "Level 49 - Medium Challenge", TimeSeconds: 44, MinAccuracy: 97.8, MaxMistakes: 1, MinWPM: 39.5, IsBoss: false},
+		{Name: "Medium Boss 6 - Accuracy Master", TimeSeconds: 45, MinAccuracy: 97.9, MaxMistakes: 1, MinWPM: 40, IsBoss: true},
 
-		{Name: "Level 51 - Hard Mastery", TimeSeconds: 45, MinAccuracy: 97.0, MaxMistakes: 29, MinChars: 670, MinWords: 134, IsBoss: false},
-		{Name: "Level 52 - Hard Mastery", TimeSeconds: 45, MinAccuracy: 97.05, MaxMistakes: 28, MinChars: 690, MinWords: 138, IsBoss: false},
-		{Name: "Level 53 - Hard Mastery", TimeSeconds: 46, MinAccuracy: 97.1, MaxMistakes: 27, MinChars: 710, MinWords: 142, IsBoss: false},
-		{Name: "Level 54 - Hard Mastery", TimeSeconds: 46, MinAccuracy: 97.15, MaxMistakes: 26, MinChars: 730, MinWords: 146, IsBoss: false},
-		{Name: "Hard Boss 1 - Ultimate Test", TimeSeconds: 46, MinAccuracy: 97.2, MaxMistakes: 25, MinChars: 750, MinWords: 150, IsBoss: true},
-		{Name: "Level 56 - Hard Mastery", TimeSeconds: 47, MinAccuracy: 97.25, MaxMistakes: 24, MinChars: 770, MinWords: 154, IsBoss: false},
-		{Name: "Level 57 - Hard Mastery", TimeSeconds: 47, MinAccuracy: 97.3, MaxMistakes: 23, MinChars: 790, MinWords: 158, IsBoss: false},
-		{Name: "Level 58 - Hard Mastery", TimeSeconds: 47, MinAccuracy: 97.35, MaxMistakes: 22, MinChars: 810, MinWords: 162, IsBoss: false},
-		{Name: "Level 59 - Hard Mastery", TimeSeconds: 48, MinAccuracy: 97.4, MaxMistakes: 21, MinChars: 830, MinWords: 166, IsBoss: false},
-		{Name: "Hard Boss 2 - Ultimate Test", TimeSeconds: 48, MinAccuracy: 97.45, MaxMistakes: 20, MinChars: 850, MinWords: 170, IsBoss: true},
-		{Name: "Level 61 - Hard Mastery", TimeSeconds: 48, MinAccuracy: 97.5, MaxMistakes: 19, MinChars: 870, MinWords: 174, IsBoss: false},
-		{Name: "Level 62 - Hard Mastery", TimeSeconds: 49, MinAccuracy: 97.55, MaxMistakes: 18, MinChars: 890, MinWords: 178, IsBoss: false},
-		{Name: "Level 63 - Hard Mastery", TimeSeconds: 49, MinAccuracy: 97.6, MaxMistakes: 17, MinChars: 910, MinWords: 182, IsBoss: false},
-		{Name: "Level 64 - Hard Mastery", TimeSeconds: 49, MinAccuracy: 97.65, MaxMistakes: 16, MinChars: 930, MinWords: 186, IsBoss: false},
-		{Name: "Hard Boss 3 - Ultimate Test", TimeSeconds: 50, MinAccuracy: 97.7, MaxMistakes: 15, MinChars: 950, MinWords: 190, IsBoss: true},
-		{Name: "Level 66 - Hard Mastery", TimeSeconds: 50, MinAccuracy: 97.75, MaxMistakes: 14, MinChars: 970, MinWords: 194, IsBoss: false},
-		{Name: "Level 67 - Hard Mastery", TimeSeconds: 50, MinAccuracy: 97.8, MaxMistakes: 13, MinChars: 990, MinWords: 198, IsBoss: false},
-		{Name: "Level 68 - Hard Mastery", TimeSeconds: 51, MinAccuracy: 97.85, MaxMistakes: 12, MinChars: 1010, MinWords: 202, IsBoss: false},
-		{Name: "Level 69 - Hard Mastery", TimeSeconds: 51, MinAccuracy: 97.9, MaxMistakes: 11, MinChars: 1030, MinWords: 206, IsBoss: false},
-		{Name: "Hard Boss 4 - Ultimate Test", TimeSeconds: 51, MinAccuracy: 97.95, MaxMistakes: 10, MinChars: 1050, MinWords: 210, IsBoss: true},
-		{Name: "Level 71 - Hard Mastery", TimeSeconds: 52, MinAccuracy: 98.0, MaxMistakes: 9, MinChars: 1070, MinWords: 214, IsBoss: false},
-		{Name: "Level 72 - Hard Mastery", TimeSeconds: 52, MinAccuracy: 98.05, MaxMistakes: 8, MinChars: 1090, MinWords: 218, IsBoss: false},
-		{Name: "Level 73 - Hard Mastery", TimeSeconds: 52, MinAccuracy: 98.1, MaxMistakes: 7, MinChars: 1110, MinWords: 222, IsBoss: false},
-		{Name: "Level 74 - Hard Mastery", TimeSeconds: 53, MinAccuracy: 98.15, MaxMistakes: 6, MinChars: 1130, MinWords: 226, IsBoss: false},
-		{Name: "Hard Boss 5 - Ultimate Test", TimeSeconds: 53, MinAccuracy: 98.2, MaxMistakes: 5, MinChars: 1150, MinWords: 230, IsBoss: true},
-		{Name: "Level 76 - Hard Mastery", TimeSeconds: 53, MinAccuracy: 98.25, MaxMistakes: 4, MinChars: 1170, MinWords: 234, IsBoss: false},
-		{Name: "Level 77 - Hard Mastery", TimeSeconds: 54, MinAccuracy: 98.3, MaxMistakes: 3, MinChars: 1190, MinWords: 238, IsBoss: false},
-		{Name: "Level 78 - Hard Mastery", TimeSeconds: 54, MinAccuracy: 98.35, MaxMistakes: 2, MinChars: 1210, MinWords: 242, IsBoss: false},
-		{Name: "Level 79 - Hard Mastery", TimeSeconds: 54, MinAccuracy: 98.4, MaxMistakes: 1, MinChars: 1230, MinWords: 246, IsBoss: false},
-		{Name: "Hard Boss 6 - Ultimate Test", TimeSeconds: 55, MinAccuracy: 98.45, MaxMistakes: 1, MinChars: 1250, MinWords: 250, IsBoss: true},
+		{Name: "Level 51 - Hard Mastery", TimeSeconds: 45, MinAccuracy: 97.0, MaxMistakes: 29, MinWPM: 40, IsBoss: false},
+		{Name: "Level 52 - Hard Mastery", TimeSeconds: 45, MinAccuracy: 97.05, MaxMistakes: 28, MinWPM: 40.5, IsBoss: false},
+		{Name: "Level 53 - Hard Mastery", TimeSeconds: 46, MinAccuracy: 97.1, MaxMistakes: 27, MinWPM: 41, IsBoss: false},
+		{Name: "Level 54 - Hard Mastery", TimeSeconds: 46, MinAccuracy: 97.15, MaxMistakes: 26, MinWPM: 41.5, IsBoss: false},
+		{Name: "Hard Boss 1 - Ultimate Test", TimeSeconds: 46, MinAccuracy: 97.2, MaxMistakes: 25, MinWPM: 42, IsBoss: true},
+		{Name: "Level 56 - Hard Mastery", TimeSeconds: 47, MinAccuracy: 97.25, MaxMistakes: 24, MinWPM: 42.5, IsBoss: false},
+		{Name: "Level 57 - Hard Mastery", TimeSeconds: 47, MinAccuracy: 97.3, MaxMistakes: 23, MinWPM: 43, IsBoss: false},
+		{Name: "Level 58 - Hard Mastery", TimeSeconds: 47, MinAccuracy: 97.35, MaxMistakes: 22, MinWPM: 43.5, IsBoss: false},
+		{Name: "Level 59 - Hard Mastery", TimeSeconds: 48, MinAccuracy: 97.4, MaxMistakes: 21, MinWPM: 44, IsBoss: false},
+		{Name: "Hard Boss 2 - Ultimate Test", TimeSeconds: 48, MinAccuracy: 97.45, MaxMistakes: 20, MinWPM: 44.5, IsBoss: true},
+		{Name: "Level 61 - Hard Mastery", TimeSeconds: 48, MinAccuracy: 97.5, MaxMistakes: 19, MinWPM: 45, IsBoss: false},
+		{Name: "Level 62 - Hard Mastery", TimeSeconds: 49, MinAccuracy: 97.55, MaxMistakes: 18, MinWPM: 45.5, IsBoss: false},
+		{Name: "Level 63 - Hard Mastery", TimeSeconds: 49, MinAccuracy: 97.6, MaxMistakes: 17, MinWPM: 46, IsBoss: false},
+		{Name: "Level 64 - Hard Mastery", TimeSeconds: 49, MinAccuracy: 97.65, MaxMistakes: 16, MinWPM: 46.5, IsBoss: false},
+		{Name: "Hard Boss 3 - Ultimate Test", TimeSeconds: 50, MinAccuracy: 97.7, MaxMistakes: 15, MinWPM: 47, IsBoss: true},
+		{Name: "Level 66 - Hard Mastery", TimeSeconds: 50, MinAccuracy: 97.75, MaxMistakes: 14, MinWPM: 48, IsBoss: false},
+		{Name: "Level 67 - Hard Mastery", TimeSeconds: 50, MinAccuracy: 97.8, MaxMistakes: 13, MinWPM: 48.5, IsBoss: false},
+		{Name: "Level 68 - Hard Mastery", TimeSeconds: 51, MinAccuracy: 97.85, MaxMistakes: 12, MinWPM: 49, IsBoss: false},
+		{Name: "Level 69 - Hard Mastery", TimeSeconds: 51, MinAccuracy: 97.9, MaxMistakes: 11, MinWPM: 49.5, IsBoss: false},
+		{Name: "Hard Boss 4 - Ultimate Test", TimeSeconds: 51, MinAccuracy: 97.95, MaxMistakes: 10, MinWPM: 50, IsBoss: true},
+		{Name: "Level 71 - Hard Mastery", TimeSeconds: 52, MinAccuracy: 98.0, MaxMistakes: 9, MinWPM: 50.5, IsBoss: false},
+		{Name: "Level 72 - Hard Mastery", TimeSeconds: 52, MinAccuracy: 98.05, MaxMistakes: 8, MinWPM: 51, IsBoss: false},
+		{Name: "Level 73 - Hard Mastery", TimeSeconds: 52, MinAccuracy: 98.1, MaxMistakes: 7, MinWPM: 51.5, IsBoss: false},
+		{Name: "Level 74 - Hard Mastery", TimeSeconds: 53, MinAccuracy: 98.15, MaxMistakes: 6, MinWPM: 52, IsBoss: false},
+		{Name: "Hard Boss 5 - Ultimate Test", TimeSeconds: 53, MinAccuracy: 98.2, MaxMistakes: 5, MinWPM: 52.5, IsBoss: true},
+		{Name: "Level 76 - Hard Mastery", TimeSeconds: 53, MinAccuracy: 98.25, MaxMistakes: 4, MinWPM: 53, IsBoss: false},
+		{Name: "Level 77 - Hard Mastery", TimeSeconds: 54, MinAccuracy: 98.3, MaxMistakes: 3, MinWPM: 53.5, IsBoss: false},
+		{Name: "Level 78 - Hard Mastery", TimeSeconds: 54, MinAccuracy: 98.35, MaxMistakes: 2, MinWPM: 54, IsBoss: false},
+		{Name: "Level 79 - Hard Mastery", TimeSeconds: 54, MinAccuracy: 98.4, MaxMistakes: 1, MinWPM: 54.5, IsBoss: false},
+		{Name: "Hard Boss 6 - Ultimate Test", TimeSeconds: 55, MinAccuracy: 98.45, MaxMistakes: 1, MinWPM: 55, IsBoss: true},
 
-		{Name: "Level 81 - Expert Elite", TimeSeconds: 50, MinAccuracy: 98.0, MaxMistakes: 12, MinChars: 1275, MinWords: 255, IsBoss: false},
-		{Name: "Level 82 - Expert Elite", TimeSeconds: 50, MinAccuracy: 98.02, MaxMistakes: 11, MinChars: 1300, MinWords: 260, IsBoss: false},
-		{Name: "Level 83 - Expert Elite", TimeSeconds: 51, MinAccuracy: 98.04, MaxMistakes: 10, MinChars: 1325, MinWords: 265, IsBoss: false},
-		{Name: "Level 84 - Expert Elite", TimeSeconds: 51, MinAccuracy: 98.06, MaxMistakes: 9, MinChars: 1350, MinWords: 270, IsBoss: false},
-		{Name: "Expert Boss 1 - Legendary", TimeSeconds: 51, MinAccuracy: 98.08, MaxMistakes: 8, MinChars: 1375, MinWords: 275, IsBoss: true},
-		{Name: "Level 86 - Expert Elite", TimeSeconds: 52, MinAccuracy: 98.1, MaxMistakes: 7, MinChars: 1400, MinWords: 280, IsBoss: false},
-		{Name: "Level 87 - Expert Elite", TimeSeconds: 52, MinAccuracy: 98.12, MaxMistakes: 6, MinChars: 1425, MinWords: 285, IsBoss: false},
-		{Name: "Level 88 - Expert Elite", TimeSeconds: 52, MinAccuracy: 98.14, MaxMistakes: 5, MinChars: 1450, MinWords: 290, IsBoss: false},
-		{Name: "Level 89 - Expert Elite", TimeSeconds: 53, MinAccuracy: 98.16, MaxMistakes: 4, MinChars: 1475, MinWords: 295, IsBoss: false},
-		{Name: "Expert Boss 2 - Legendary", TimeSeconds: 53, MinAccuracy: 98.18, MaxMistakes: 3, MinChars: 1500, MinWords: 300, IsBoss: true},
-		{Name: "Level 91 - Expert Elite", TimeSeconds: 53, MinAccuracy: 98.2, MaxMistakes: 2, MinChars: 1525, MinWords: 305, IsBoss: false},
-		{Name: "Level 92 - Expert Elite", TimeSeconds: 54, MinAccuracy: 98.22, MaxMistakes: 1, MinChars: 1550, MinWords: 310, IsBoss: false},
-		{Name: "Level 93 - Expert Elite", TimeSeconds: 54, MinAccuracy: 98.24, MaxMistakes: 1, MinChars: 1575, MinWords: 315, IsBoss: false},
-		{Name: "Level 94 - Expert Elite", TimeSeconds: 54, MinAccuracy: 98.26, MaxMistakes: 1, MinChars: 1600, MinWords: 320, IsBoss: false},
-		{Name: "Expert Boss 3 - Legendary", TimeSeconds: 55, MinAccuracy: 98.28, MaxMistakes: 1, MinChars: 1625, MinWords: 325, IsBoss: true},
-		{Name: "Level 96 - Expert Elite", TimeSeconds: 55, MinAccuracy: 98.3, MaxMistakes: 1, MinChars: 1650, MinWords: 330, IsBoss: false},
-		{Name: "Level 97 - Expert Elite", TimeSeconds: 55, MinAccuracy: 98.32, MaxMistakes: 1, MinChars: 1675, MinWords: 335, IsBoss: false},
-		{Name: "Level 98 - Expert Elite", TimeSeconds: 56, MinAccuracy: 98.34, MaxMistakes: 1, MinChars: 1700, MinWords: 340, IsBoss: false},
-		{Name: "Expert Boss 4 - Legendary", TimeSeconds: 56, MinAccuracy: 98.36, MaxMistakes: 1, MinChars: 1725, MinWords: 345, IsBoss: true},
+		{Name: "Level 81 - Expert Elite", TimeSeconds: 50, MinAccuracy: 98.0, MaxMistakes: 12, MinWPM: 55, MinNetWPM: 50, IsBoss: false},
+		{Name: "Level 82 - Expert Elite", TimeSeconds: 50, MinAccuracy: 98.02, MaxMistakes: 11, MinWPM: 56, MinNetWPM: 51, IsBoss: false},
+		{Name: "Level 83 - Expert Elite", TimeSeconds: 51, MinAccuracy: 98.04, MaxMistakes: 10, MinWPM: 56.5, MinNetWPM: 51.5, IsBoss: false},
+		{Name: "Level 84 - Expert Elite", TimeSeconds: 51, MinAccuracy: 98.06, MaxMistakes: 9, MinWPM: 57.5, MinNetWPM: 52.5, IsBoss: false},
+		{Name: "Expert Boss 1 - Legendary", TimeSeconds: 51, MinAccuracy: 98.08, MaxMistakes: 8, MinWPM: 58.5, MinNetWPM: 53.5, IsBoss: true},
+		{Name: "Level 86 - Expert Elite", TimeSeconds: 52, MinAccuracy: 98.1, MaxMistakes: 7, MinWPM: 59, MinNetWPM: 54, IsBoss: false},
+		{Name: "Level 87 - Expert Elite", TimeSeconds: 52, MinAccuracy: 98.12, MaxMistakes: 6, MinWPM: 60, MinNetWPM: 55, IsBoss: false},
+		{Name: "Level 88 - Expert Elite", TimeSeconds: 52, MinAccuracy: 98.14, MaxMistakes: 5, MinWPM: 61, MinNetWPM: 56, IsBoss: false},
+		{Name: "Level 89 - Expert Elite", TimeSeconds: 53, MinAccuracy: 98.16, MaxMistakes: 4, MinWPM: 61.5, MinNetWPM: 56.5, IsBoss: false},
+		{Name: "Expert Boss 2 - Legendary", TimeSeconds: 53, MinAccuracy: 98.18, MaxMistakes: 3, MinWPM: 62.5, MinNetWPM: 57.5, IsBoss: true},
+		{Name: "Level 91 - Expert Elite", TimeSeconds: 53, MinAccuracy: 98.2, MaxMistakes: 2, MinWPM: 63.5, MinNetWPM: 58.5, IsBoss: false},
+		{Name: "Level 92 - Expert Elite", TimeSeconds: 54, MinAccuracy: 98.22, MaxMistakes: 1, MinWPM: 64, MinNetWPM: 59, IsBoss: false},
+		{Name: "Level 93 - Expert Elite", TimeSeconds: 54, MinAccuracy: 98.24, MaxMistakes: 1, MinWPM: 65, MinNetWPM: 60, IsBoss: false},
+		{Name: "Level 94 - Expert Elite", TimeSeconds: 54, MinAccuracy: 98.26, MaxMistakes: 1, MinWPM: 66, MinNetWPM: 61, IsBoss: false},
+		{Name: "Expert Boss 3 - Legendary", TimeSeconds: 55, MinAccuracy: 98.28, MaxMistakes: 1, MinWPM: 66.5, MinNetWPM: 61.5, IsBoss: true},
+		{Name: "Level 96 - Expert Elite", TimeSeconds: 55, MinAccuracy: 98.3, MaxMistakes: 1, MinWPM: 67.5, MinNetWPM: 62.5, IsBoss: false},
+		{Name: "Level 97 - Expert Elite", TimeSeconds: 55, MinAccuracy: 98.32, MaxMistakes: 1, MinWPM: 68.5, MinNetWPM: 63.5, IsBoss: false},
+		{Name: "Level 98 - Expert Elite", TimeSeconds: 56, MinAccuracy: 98.34, MaxMistakes: 1, MinWPM: 69, MinNetWPM: 64, IsBoss: false},
+		{Name: "Expert Boss 4 - Legendary", TimeSeconds: 56, MinAccuracy: 98.36, MaxMistakes: 1, MinWPM: 70, MinNetWPM: 65, IsBoss: true},
 
-		{Name: "Level 100 - Ultimate Typing God", TimeSeconds: 120, MinAccuracy: 99.5, MaxMistakes: 5, MinChars: 2000, MinWords: 400, IsBoss: true},
+		{Name: "Level 100 - Ultimate Typing God", TimeSeconds: 120, MinAccuracy: 99.5, MaxMistakes: 5, MinWPM: 80, MinNetWPM: 75, IsBoss: true},
 	}
 }
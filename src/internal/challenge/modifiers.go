@@ -0,0 +1,116 @@
+package challenge
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// Boss modifiers change how a boss round is played. Any number of them can
+// be combined on one boss.
+const (
+	ModifierMemory        = "memory"
+	ModifierReversed      = "reversed"
+	ModifierHiddenErrors  = "hidden_errors"
+	ModifierNoBackspace   = "no_backspace"
+	ModifierRandomCaps    = "random_caps"
+	ModifierShrinkingTime = "shrinking_time"
+)
+
+var modifierDescriptions = map[string]string{
+	ModifierMemory:        "Text fades after a few seconds",
+	ModifierReversed:      "Every word is spelled backwards",
+	ModifierHiddenErrors:  "Mistakes are not highlighted",
+	ModifierNoBackspace:   "Backspace is disabled",
+	ModifierRandomCaps:    "Letters are randomly capitalised",
+	ModifierShrinkingTime: "The clock speeds up as time passes",
+}
+
+// ModifierNames lists the supported modifiers in display order.
+func ModifierNames() []string {
+	return []string{
+		ModifierMemory,
+		ModifierReversed,
+		ModifierHiddenErrors,
+		ModifierNoBackspace,
+		ModifierRandomCaps,
+		ModifierShrinkingTime,
+	}
+}
+
+func ModifierDescription(name string) string {
+	return modifierDescriptions[name]
+}
+
+func validateModifiers(modifiers []string) error {
+	for _, name := range modifiers {
+		if _, ok := modifierDescriptions[name]; !ok {
+			return fmt.Errorf("unknown modifier '%s' (supported: %s)", name, strings.Join(ModifierNames(), ", "))
+		}
+	}
+	return nil
+}
+
+func hasModifier(modifiers []string, name string) bool {
+	for _, modifier := range modifiers {
+		if modifier == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTextModifiers rewrites boss text for the modifiers that change it.
+func applyTextModifiers(text string, modifiers []string) string {
+	if hasModifier(modifiers, ModifierReversed) {
+		words := strings.Fields(text)
+		for i, word := range words {
+			runes := []rune(word)
+			for a, b := 0, len(runes)-1; a < b; a, b = a+1, b-1 {
+				runes[a], runes[b] = runes[b], runes[a]
+			}
+			words[i] = string(runes)
+		}
+		text = strings.Join(words, " ")
+	}
+
+	if hasModifier(modifiers, ModifierRandomCaps) {
+		runes := []rune(text)
+		for i, r := range runes {
+			if rand.Intn(3) == 0 {
+				runes[i] = unicode.ToUpper(r)
+			}
+		}
+		text = string(runes)
+	}
+
+	return text
+}
+
+// memoryRevealSeconds is how long memory text stays visible: a second for
+// every four words, but never less than three.
+func memoryRevealSeconds(text string) int {
+	seconds := len(strings.Fields(text)) / 4
+	if seconds < 3 {
+		seconds = 3
+	}
+	return seconds
+}
+
+// shrinkingDrain is how many seconds a tick removes under shrinking time,
+// one extra for every ten seconds already played.
+func shrinkingDrain(elapsedSeconds int) int {
+	return 1 + elapsedSeconds/10
+}
+
+// shrinkingSeconds is how many real seconds a clock of limit seconds lasts
+// under shrinking time, ticking once a second.
+func shrinkingSeconds(limit int) int {
+	elapsed := 0
+	for left := limit; left > 0; {
+		elapsed++
+		left -= shrinkingDrain(elapsed)
+	}
+	return elapsed
+}
@@ -0,0 +1,206 @@
+package challenge
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gti/src/internal"
+	"gti/src/internal/config"
+
+	"github.com/BurntSushi/toml"
+)
+
+type LevelPack struct {
+	ID          string           `toml:"-"`
+	Name        string           `toml:"name"`
+	Description string           `toml:"description"`
+	Levels      []ChallengeLevel `toml:"levels"`
+}
+
+func PacksDir() string {
+	return filepath.Join(config.ConfigDir, "challenges")
+}
+
+func LoadPacks() ([]*LevelPack, error) {
+	paths, err := filepath.Glob(filepath.Join(PacksDir(), "*.toml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var packs []*LevelPack
+	var errs []error
+	for _, path := range paths {
+		pack, err := loadPackFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		packs = append(packs, pack)
+	}
+	return packs, errors.Join(errs...)
+}
+
+// LoadPack finds a pack by file name (without .toml) or by its declared name.
+func LoadPack(name string) (*LevelPack, error) {
+	path := filepath.Join(PacksDir(), name+".toml")
+	if _, err := os.Stat(path); err == nil {
+		return loadPackFile(path)
+	}
+
+	packs, err := LoadPacks()
+	if err != nil {
+		return nil, err
+	}
+	for _, pack := range packs {
+		if strings.EqualFold(pack.Name, name) {
+			return pack, nil
+		}
+	}
+	return nil, fmt.Errorf("challenge pack '%s' not found in %s", name, PacksDir())
+}
+
+func loadPackFile(path string) (*LevelPack, error) {
+	var pack LevelPack
+	if _, err := toml.DecodeFile(path, &pack); err != nil {
+		return nil, fmt.Errorf("failed to parse challenge pack %s: %w", path, err)
+	}
+
+	pack.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if pack.Name == "" {
+		pack.Name = pack.ID
+	}
+
+	if len(pack.Levels) == 0 {
+		return nil, fmt.Errorf("challenge pack %s has no levels", path)
+	}
+
+	for i := range pack.Levels {
+		level := &pack.Levels[i]
+		if level.Name == "" {
+			level.Name = fmt.Sprintf("Level %d", i+1)
+		}
+		if level.TimeSeconds <= 0 {
+			level.TimeSeconds = 30
+		}
+		if level.Language != "" && !internal.IsLanguageSupported(level.Language) {
+			return nil, fmt.Errorf("challenge pack %s: level %d uses unsupported language '%s'", path, i+1, level.Language)
+		}
+		if err := validateModifiers(level.Modifiers); err != nil {
+			return nil, fmt.Errorf("challenge pack %s: level %d: %w", path, i+1, err)
+		}
+		if len(level.Modifiers) > 0 && !level.IsBoss {
+			return nil, fmt.Errorf("challenge pack %s: level %d sets modifiers but is not a boss level (add is_boss = true)", path, i+1)
+		}
+		for j := range level.HiddenBosses {
+			boss := &level.HiddenBosses[j]
+			if err := validateModifiers(boss.Modifiers); err != nil {
+				return nil, fmt.Errorf("challenge pack %s: level %d hidden boss %d: %w", path, i+1, j+1, err)
+			}
+			if boss.TriggerChunk < 1 {
+				return nil, fmt.Errorf("challenge pack %s: level %d hidden boss %d needs trigger_chunk >= 1", path, i+1, j+1)
+			}
+			if boss.Name == "" {
+				boss.Name = fmt.Sprintf("Hidden Boss %d", j+1)
+			}
+			if boss.Words <= 0 {
+				boss.Words = 10
+			}
+			if boss.TimeSeconds <= 0 {
+				boss.TimeSeconds = 15
+			}
+		}
+	}
+
+	return &pack, nil
+}
+
+// BuildLevels turns level definitions into playable levels.
+func BuildLevels(defs []ChallengeLevel) []Level {
+	levels := make([]Level, 0, len(defs))
+
+	for i, def := range defs {
+		level := Level{
+			Name:        def.Name,
+			Difficulty:  fmt.Sprintf("lv%d", i+1),
+			Time:        def.TimeSeconds,
+			ChunkSize:   10,
+			Message:     "Level completed!",
+			IsBoss:      def.IsBoss,
+			MinAccuracy: def.MinAccuracy,
+			MaxMistakes: def.MaxMistakes,
+			MinChars:    def.MinChars,
+			MinWords:    def.MinWords,
+			MinWPM:      def.MinWPM,
+			MinNetWPM:   def.MinNetWPM,
+			Language:    def.Language,
+			TextSource:  def.TextSource,
+		}
+
+		if def.IsBoss {
+			words := def.MinChars / 5
+			if words < 1 {
+				words = 1
+			}
+			level.BossRound = &BossRound{
+				Words:     words,
+				TimeLimit: def.TimeSeconds,
+				Name:      def.Name,
+				Modifiers: def.Modifiers,
+			}
+		}
+
+		for _, hidden := range def.HiddenBosses {
+			level.BossRounds = append(level.BossRounds, BossRound{
+				Words:        hidden.Words,
+				TimeLimit:    hidden.TimeSeconds,
+				Name:         hidden.Name,
+				TriggerChunk: hidden.TriggerChunk,
+				Modifiers:    hidden.Modifiers,
+			})
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels
+}
+
+var sourceWords = make(map[string][]string)
+var sourceMutex sync.Mutex
+
+// wordsFromSource picks a random run of words from a text file. Relative
+// paths are resolved against the packs directory.
+func wordsFromSource(source string, count int) string {
+	path := config.ExpandPath(strings.TrimPrefix(source, "file:"))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(PacksDir(), path)
+	}
+
+	sourceMutex.Lock()
+	words, ok := sourceWords[path]
+	if !ok {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			words = strings.Fields(string(data))
+		}
+		sourceWords[path] = words
+	}
+	sourceMutex.Unlock()
+
+	if len(words) == 0 {
+		return ""
+	}
+	if count >= len(words) {
+		return strings.Join(words, " ")
+	}
+
+	start := rand.Intn(len(words) - count + 1)
+	return strings.Join(words[start:start+count], " ")
+}
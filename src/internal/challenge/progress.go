@@ -8,16 +8,46 @@ import (
 	"gti/src/internal/config"
 )
 
+// progressVersion is the current progress file format. Version 2 counts
+// levels from 0 and uses -1 for "no level completed"; earlier files used 0
+// for both that and a completed first level.
+const progressVersion = 2
+
+// noLevelCompleted is HighestLevelCompleted before any level is passed.
+const noLevelCompleted = -1
+
 type GameProgress struct {
-	HighestLevelCompleted int `json:"highest_level_completed"`
+	Version               int                `json:"version"`
+	HighestLevelCompleted int                `json:"highest_level_completed"`
+	Levels                map[int]LevelScore `json:"levels,omitempty"`
 }
 
-func LoadProgress(cfg *config.Config) (*GameProgress, error) {
-	progressFile := filepath.Join(config.ConfigDir, "challenge_progress.json")
+func newProgress() *GameProgress {
+	return &GameProgress{Version: progressVersion, HighestLevelCompleted: noLevelCompleted}
+}
+
+// LevelScore is the best result recorded for a single level.
+type LevelScore struct {
+	BestWPM      float64 `json:"best_wpm"`
+	BestAccuracy float64 `json:"best_accuracy"`
+	Stars        int     `json:"stars"`
+	BossCleared  bool    `json:"boss_cleared"`
+	Completions  int     `json:"completions"`
+}
 
-	progress := &GameProgress{
-		HighestLevelCompleted: 0,
+// progressFile keeps built-in progress in challenge_progress.json and gives
+// every level pack its own file next to it.
+func progressFile(pack string) string {
+	if pack == "" {
+		return filepath.Join(config.UserConfigDir(), "challenge_progress.json")
 	}
+	return filepath.Join(config.UserConfigDir(), "challenge_progress_"+pack+".json")
+}
+
+func LoadProgress(cfg *config.Config, pack string) (*GameProgress, error) {
+	progressFile := progressFile(pack)
+
+	progress := newProgress()
 
 	if _, err := os.Stat(progressFile); os.IsNotExist(err) {
 		return progress, nil
@@ -29,16 +59,37 @@ func LoadProgress(cfg *config.Config) (*GameProgress, error) {
 	}
 	defer file.Close()
 
+	progress.Version = 0
 	err = json.NewDecoder(file).Decode(progress)
 	if err != nil {
-		return &GameProgress{HighestLevelCompleted: 0}, nil
+		return newProgress(), nil
+	}
+	if progress.Version < progressVersion {
+		migrateProgress(progress)
 	}
 
 	return progress, nil
 }
 
-func SaveProgress(cfg *config.Config, progress *GameProgress) error {
-	progressFile := filepath.Join(config.ConfigDir, "challenge_progress.json")
+// migrateProgress upgrades a file written before version 2. A highest level
+// of 0 there is ambiguous, so the level scores decide whether the first
+// level was really completed.
+func migrateProgress(progress *GameProgress) {
+	highest := noLevelCompleted
+	if progress.HighestLevelCompleted > 0 {
+		highest = progress.HighestLevelCompleted
+	}
+	for level, score := range progress.Levels {
+		if score.Completions > 0 && level > highest {
+			highest = level
+		}
+	}
+	progress.HighestLevelCompleted = highest
+	progress.Version = progressVersion
+}
+
+func SaveProgress(cfg *config.Config, pack string, progress *GameProgress) error {
+	progressFile := progressFile(pack)
 
 	file, err := os.Create(progressFile)
 	if err != nil {
@@ -46,11 +97,12 @@ func SaveProgress(cfg *config.Config, progress *GameProgress) error {
 	}
 	defer file.Close()
 
+	progress.Version = progressVersion
 	return json.NewEncoder(file).Encode(progress)
 }
 
-func GetStartingLevel(cfg *config.Config) int {
-	progress, err := LoadProgress(cfg)
+func GetStartingLevel(cfg *config.Config, pack string) int {
+	progress, err := LoadProgress(cfg, pack)
 	if err != nil {
 		return 0
 	}
@@ -58,16 +110,75 @@ func GetStartingLevel(cfg *config.Config) int {
 	return progress.HighestLevelCompleted + 1
 }
 
-func UpdateProgress(cfg *config.Config, levelCompleted int) error {
-	progress, err := LoadProgress(cfg)
+func UpdateProgress(cfg *config.Config, pack string, levelCompleted int) error {
+	progress, err := LoadProgress(cfg, pack)
 	if err != nil {
-		progress = &GameProgress{HighestLevelCompleted: 0}
+		progress = newProgress()
 	}
 
 	if levelCompleted > progress.HighestLevelCompleted {
 		progress.HighestLevelCompleted = levelCompleted
-		return SaveProgress(cfg, progress)
+		return SaveProgress(cfg, pack, progress)
 	}
 
 	return nil
 }
+
+// IsUnlocked reports whether a level can be picked from the level map: the
+// first level always, and every other level once the one before it is done.
+func (p *GameProgress) IsUnlocked(level int) bool {
+	return level <= p.HighestLevelCompleted+1
+}
+
+func (p *GameProgress) TotalStars() int {
+	total := 0
+	for _, score := range p.Levels {
+		total += score.Stars
+	}
+	return total
+}
+
+// StarRating grades a passed level: one star for clearing it, a second for
+// accuracy halfway between the requirement and perfect, and a third for 99%
+// accuracy with every boss defeated.
+func StarRating(level Level, accuracy float64, bossCleared bool) int {
+	stars := 1
+	if accuracy >= (level.MinAccuracy+100)/2 {
+		stars++
+		if accuracy >= 99 && bossCleared {
+			stars++
+		}
+	}
+	return stars
+}
+
+// RecordLevelResult stores a completed level, keeping the best scores seen.
+func RecordLevelResult(cfg *config.Config, pack string, level int, wpm, accuracy float64, stars int, bossCleared bool) error {
+	progress, err := LoadProgress(cfg, pack)
+	if err != nil {
+		progress = newProgress()
+	}
+	if progress.Levels == nil {
+		progress.Levels = make(map[int]LevelScore)
+	}
+
+	score := progress.Levels[level]
+	score.Completions++
+	if wpm > score.BestWPM {
+		score.BestWPM = wpm
+	}
+	if accuracy > score.BestAccuracy {
+		score.BestAccuracy = accuracy
+	}
+	if stars > score.Stars {
+		score.Stars = stars
+	}
+	score.BossCleared = score.BossCleared || bossCleared
+	progress.Levels[level] = score
+
+	if level > progress.HighestLevelCompleted {
+		progress.HighestLevelCompleted = level
+	}
+
+	return SaveProgress(cfg, pack, progress)
+}
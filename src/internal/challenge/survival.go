@@ -0,0 +1,342 @@
+package challenge
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gti/src/internal"
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	survivalStartTime      = 10 * time.Second
+	survivalWordBonus      = 1500 * time.Millisecond
+	survivalComboBonus     = 250 * time.Millisecond
+	survivalMistakePenalty = 2 * time.Second
+	survivalChunkWords     = 8
+	survivalWordsPerStage  = 15
+	survivalMinWordLength  = 3
+	survivalMaxWordLength  = 9
+	survivalTickInterval   = 100 * time.Millisecond
+)
+
+// SurvivalModel is an endless challenge: the clock starts short, finished
+// words buy time, mistakes cost time and words get longer as the run goes on.
+type SurvivalModel struct {
+	config *config.Config
+	sess   *session.Session
+	phase  string
+	width  int
+	height int
+
+	deadline     time.Time
+	startTime    time.Time
+	endTime      time.Time
+	tickID       int
+	wordIndex    int
+	words        int
+	chars        int
+	mistakes     int
+	score        int
+	combo        int
+	longestCombo int
+
+	rank     int
+	scores   []SurvivalScore
+	gaveUp   bool
+	saveErr  error
+	scoreErr error
+}
+
+func NewSurvivalModel(cfg *config.Config) SurvivalModel {
+	m := SurvivalModel{config: cfg}
+	m.reset()
+	return m
+}
+
+func (m *SurvivalModel) reset() {
+	now := time.Now()
+	m.sess = session.NewSessionWithChallenge(m.config, "survival")
+	m.phase = "playing"
+	m.deadline = now.Add(survivalStartTime)
+	m.startTime = now
+	m.tickID++
+	m.words, m.chars, m.mistakes = 0, 0, 0
+	m.score, m.combo, m.longestCombo = 0, 0, 0
+	m.rank = 0
+	m.gaveUp = false
+	m.saveErr = nil
+	m.scoreErr = nil
+	m.nextChunk()
+}
+
+// stage is the current difficulty step, starting at zero.
+func (m SurvivalModel) stage() int {
+	return m.words / survivalWordsPerStage
+}
+
+func (m *SurvivalModel) nextChunk() {
+	minLength := survivalMinWordLength + m.stage()
+	if minLength > survivalMaxWordLength {
+		minLength = survivalMaxWordLength
+	}
+	m.sess.SetText(internal.GenerateWordsMinLength(survivalChunkWords, m.config.Language.Default, minLength))
+	m.sess.SetTier(fmt.Sprintf("survival %d", m.stage()+1))
+	m.sess.ExternalMistakes = m.mistakes
+	m.wordIndex = 0
+	m.sess.Start()
+}
+
+func (m SurvivalModel) timeLeft() time.Duration {
+	left := time.Until(m.deadline)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+func (m SurvivalModel) Init() tea.Cmd {
+	return tea.Batch(tea.EnterAltScreen, m.tick())
+}
+
+func (m SurvivalModel) tick() tea.Cmd {
+	id := m.tickID
+	return tea.Tick(survivalTickInterval, func(t time.Time) tea.Msg {
+		return survivalTickMsg{ID: id}
+	})
+}
+
+type survivalTickMsg struct {
+	ID int
+}
+
+func (m SurvivalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case survivalTickMsg:
+		if msg.ID != m.tickID || m.phase != "playing" {
+			return m, nil
+		}
+		if m.timeLeft() <= 0 {
+			m.endRun()
+			return m, nil
+		}
+		m.sess.RemainingTimeDisplay = int(m.timeLeft().Seconds() + 0.999)
+		return m, m.tick()
+	case session.SessionCompleteMsg:
+		if m.phase == "playing" {
+			m.nextChunk()
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.sess.MarkLayoutDirty()
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m *SurvivalModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.phase == "over" {
+		switch key.String() {
+		case "enter", "r":
+			m.reset()
+			return m, m.tick()
+		case "q", "esc":
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	if key.String() == "esc" {
+		m.gaveUp = true
+		m.endRun()
+		return m, nil
+	}
+
+	before := m.sess.GetMistakes()
+	cmd := m.sess.HandleInput(key)
+	if delta := m.sess.GetMistakes() - before; delta > 0 {
+		m.mistakes += delta
+		m.combo = 0
+		m.deadline = m.deadline.Add(-survivalMistakePenalty * time.Duration(delta))
+	}
+	m.scoreFinishedWords()
+
+	if m.timeLeft() <= 0 {
+		m.endRun()
+		return m, nil
+	}
+	return m, cmd
+}
+
+// scoreFinishedWords awards time for every word the cursor has moved past.
+// Only words typed exactly keep the combo going.
+func (m *SurvivalModel) scoreFinishedWords() {
+	text := m.sess.GetText()
+	typed := m.sess.TypedText()
+	words := strings.Fields(text)
+
+	start := 0
+	for i := 0; i < m.wordIndex; i++ {
+		start += len(words[i]) + 1
+	}
+
+	for m.wordIndex < len(words) {
+		word := words[m.wordIndex]
+		end := start + len(word)
+		last := m.wordIndex == len(words)-1
+		if len(typed) <= end && !(last && len(typed) >= end) {
+			return
+		}
+
+		m.words++
+		m.chars += len(word) + 1
+		if typed[start:end] == word {
+			m.combo++
+			if m.combo > m.longestCombo {
+				m.longestCombo = m.combo
+			}
+			m.score += len(word) * (10 + m.combo)
+			m.deadline = m.deadline.Add(survivalWordBonus + survivalComboBonus*time.Duration(m.combo/10))
+		} else {
+			m.combo = 0
+		}
+
+		m.wordIndex++
+		start = end + 1
+	}
+}
+
+func (m *SurvivalModel) endRun() {
+	m.phase = "over"
+	m.endTime = time.Now()
+	m.tickID++
+
+	duration := m.endTime.Sub(m.startTime)
+	wpm := session.CalculateWPM(m.chars, duration)
+	accuracy := session.CalculateAccuracy(m.chars, m.mistakes)
+
+	m.rank, m.scoreErr = AddSurvivalScore(SurvivalScore{
+		Score:        m.score,
+		Words:        m.words,
+		LongestCombo: m.longestCombo,
+		WPM:          wpm,
+		Accuracy:     accuracy,
+		DurationMs:   duration.Milliseconds(),
+	})
+	m.scores, _ = LoadSurvivalScores()
+
+	m.saveErr = session.SaveSessionRecord(m.config, &session.SessionRecord{
+		Mode:       "survival",
+		Tier:       fmt.Sprintf("stage%d", m.stage()+1),
+		Language:   m.config.Language.Default,
+		TextSource: "words",
+		TextLength: m.chars,
+		DurationMs: duration.Milliseconds(),
+		WPM:        wpm,
+		CPM:        wpm * 5,
+		Accuracy:   accuracy,
+		Mistakes:   m.mistakes,
+	})
+}
+
+func (m SurvivalModel) View() string {
+	if m.width < 40 || m.height < 10 {
+		return "Terminal too small. Please resize to at least 40x10.\nPress Ctrl+C to quit."
+	}
+
+	if m.phase == "over" {
+		return m.viewGameOver()
+	}
+
+	left := m.timeLeft()
+	color := m.config.Theme.Colors.Correct
+	if left < 5*time.Second {
+		color = m.config.Theme.Colors.Incorrect
+	}
+	header := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(color)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Bold(true).
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render(fmt.Sprintf("SURVIVAL  %.1fs  Score %d  Combo %d  Stage %d", left.Seconds(), m.score, m.combo, m.stage()+1))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, m.sess.View(m.width, m.height-1))
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(content)
+}
+
+func (m SurvivalModel) viewGameOver() string {
+	duration := m.endTime.Sub(m.startTime)
+
+	var b strings.Builder
+	if m.gaveUp {
+		b.WriteString("Run ended.\n\n")
+	} else {
+		b.WriteString("Out of time!\n\n")
+	}
+	b.WriteString(fmt.Sprintf("Score: %d\n", m.score))
+	b.WriteString(fmt.Sprintf("Words: %d\n", m.words))
+	b.WriteString(fmt.Sprintf("Longest combo: %d\n", m.longestCombo))
+	b.WriteString(fmt.Sprintf("Survived: %.1fs\n", duration.Seconds()))
+	switch {
+	case m.rank == 1:
+		b.WriteString("\nNew high score!\n")
+	case m.rank > 1:
+		b.WriteString(fmt.Sprintf("\nTop %d, rank #%d\n", survivalScoreLimit, m.rank))
+	}
+	if m.scoreErr != nil {
+		b.WriteString(fmt.Sprintf("\nNot saved to high scores: %v\n", m.scoreErr))
+	}
+	if m.saveErr != nil {
+		b.WriteString(fmt.Sprintf("\nNot saved to history: %v\n", m.saveErr))
+	}
+
+	b.WriteString("\nHigh Scores\n")
+	b.WriteString(fmt.Sprintf("%-3s %7s %6s %6s %11s\n", "#", "Score", "Words", "Combo", "Date"))
+	for i, score := range m.scores {
+		marker := " "
+		if i+1 == m.rank {
+			marker = "▶"
+		}
+		b.WriteString(fmt.Sprintf("%s%-2d %7d %6d %6d %11s\n", marker, i+1, score.Score, score.Words, score.LongestCombo, score.Timestamp.Format("Jan 02")))
+	}
+
+	b.WriteString("\nPress Enter to play again, Q to quit")
+	return m.renderDialog(b.String())
+}
+
+func (m SurvivalModel) renderDialog(content string) string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		Padding(1, 2).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func StartSurvival() error {
+	cfg := config.GetConfig()
+	model := NewSurvivalModel(cfg)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
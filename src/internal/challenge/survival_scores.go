@@ -0,0 +1,81 @@
+package challenge
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+const survivalScoreLimit = 10
+
+type SurvivalScore struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Score        int       `json:"score"`
+	Words        int       `json:"words"`
+	LongestCombo int       `json:"longest_combo"`
+	WPM          float64   `json:"wpm"`
+	Accuracy     float64   `json:"accuracy"`
+	DurationMs   int64     `json:"duration_ms"`
+}
+
+func survivalScoresFile() string {
+	return filepath.Join(config.UserDataDir(), "survival_scores.json")
+}
+
+func LoadSurvivalScores() ([]SurvivalScore, error) {
+	data, err := os.ReadFile(survivalScoresFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []SurvivalScore{}, nil
+		}
+		return nil, err
+	}
+
+	var scores []SurvivalScore
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return []SurvivalScore{}, nil
+	}
+	return scores, nil
+}
+
+// AddSurvivalScore inserts a run into the high-score table and returns its
+// 1-based rank, or 0 when it did not make the table.
+func AddSurvivalScore(score SurvivalScore) (int, error) {
+	scores, err := LoadSurvivalScores()
+	if err != nil {
+		return 0, err
+	}
+
+	score.Timestamp = time.Now()
+	scores = append(scores, score)
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+
+	rank := 0
+	for i := range scores {
+		if scores[i].Timestamp.Equal(score.Timestamp) && scores[i].Score == score.Score {
+			rank = i + 1
+			break
+		}
+	}
+	if len(scores) > survivalScoreLimit {
+		scores = scores[:survivalScoreLimit]
+	}
+	if rank > survivalScoreLimit {
+		rank = 0
+	}
+
+	if err := os.MkdirAll(filepath.Dir(survivalScoresFile()), 0755); err != nil {
+		return rank, err
+	}
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return rank, err
+	}
+	return rank, os.WriteFile(survivalScoresFile(), data, 0644)
+}
@@ -2,8 +2,6 @@ package config
 
 import (
 	"path/filepath"
-
-	"github.com/adrg/xdg"
 )
 
 const DefaultPracticeText = "Typing is not about speed alone, it is about accuracy, rhythm, and calm focus."
@@ -15,6 +13,7 @@ type Config struct {
 	Language LanguageConfig `toml:"language"`
 	Network  NetworkConfig  `toml:"network"`
 	History  HistoryConfig  `toml:"history"`
+	Goals    GoalsConfig    `toml:"goals"`
 }
 
 type DisplayConfig struct {
@@ -63,8 +62,24 @@ type NetworkConfig struct {
 }
 
 type HistoryConfig struct {
-	Enabled bool   `toml:"enabled"`
-	File    string `toml:"file"`
+	Enabled  bool   `toml:"enabled"`
+	Backend  string `toml:"backend"`
+	File     string `toml:"file"`
+	Database string `toml:"database"`
+}
+
+// GoalsConfig holds practice goals. A zero value means the goal is not set.
+// TargetDate is a YYYY-MM-DD date for reaching TargetWPM.
+type GoalsConfig struct {
+	MinutesPerDay  int     `toml:"minutes_per_day" json:"minutes_per_day,omitempty"`
+	SessionsPerDay int     `toml:"sessions_per_day" json:"sessions_per_day,omitempty"`
+	MinutesPerWeek int     `toml:"minutes_per_week" json:"minutes_per_week,omitempty"`
+	TargetWPM      float64 `toml:"target_wpm" json:"target_wpm,omitempty"`
+	TargetDate     string  `toml:"target_date" json:"target_date,omitempty"`
+}
+
+func (g GoalsConfig) IsSet() bool {
+	return g.MinutesPerDay > 0 || g.SessionsPerDay > 0 || g.MinutesPerWeek > 0 || g.TargetWPM > 0
 }
 
 func DefaultConfig() *Config {
@@ -107,8 +122,10 @@ func DefaultConfig() *Config {
 			TimeoutMs: 5000,
 		},
 		History: HistoryConfig{
-			Enabled: true,
-			File:    filepath.Join(xdg.DataHome, "gti", "history.jsonl"),
+			Enabled:  true,
+			Backend:  "jsonl",
+			File:     filepath.Join(UserDataDir(), "history.jsonl"),
+			Database: filepath.Join(UserDataDir(), "history.db"),
 		},
 	}
 }
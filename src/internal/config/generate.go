@@ -7,7 +7,7 @@ import (
 )
 
 func GenerateConfig() error {
-	dirs := []string{ConfigDir, DataDir, CacheDir}
+	dirs := []string{ConfigDir, DataDir, CacheDir, UserConfigDir(), UserDataDir()}
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return err
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultProfile is the name shown for the unnamed profile that uses the
+// top-level config and data directories.
+const DefaultProfile = "default"
+
+// Profile is the active profile, empty for the default one.
+var Profile string
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+type ProfileState struct {
+	Current string `json:"current,omitempty"`
+	Picker  bool   `json:"picker,omitempty"`
+}
+
+func ProfilesDir() string {
+	return filepath.Join(DataDir, "profiles")
+}
+
+func ProfileDir(name string) string {
+	return filepath.Join(ProfilesDir(), name)
+}
+
+func profileStateFile() string {
+	return filepath.Join(ProfilesDir(), "profiles.json")
+}
+
+// UserConfigDir holds per-user settings and progress: the config directory,
+// or the active profile's directory.
+func UserConfigDir() string {
+	if Profile == "" {
+		return ConfigDir
+	}
+	return ProfileDir(Profile)
+}
+
+// UserDataDir holds per-user records such as history and high scores: the
+// data directory, or the active profile's directory.
+func UserDataDir() string {
+	if Profile == "" {
+		return DataDir
+	}
+	return ProfileDir(Profile)
+}
+
+func ValidateProfileName(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("'%s' is reserved for the default profile", DefaultProfile)
+	}
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s': use up to 32 letters, digits, '-' or '_'", name)
+	}
+	return nil
+}
+
+func ProfileExists(name string) bool {
+	if name == "" || name == DefaultProfile {
+		return true
+	}
+	info, err := os.Stat(ProfileDir(name))
+	return err == nil && info.IsDir()
+}
+
+// UseProfile points config and per-user files at the named profile. An
+// empty name or "default" selects the default profile.
+func UseProfile(name string) error {
+	if name == DefaultProfile {
+		name = ""
+	}
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+
+	Profile = name
+	if name != "" {
+		ConfigFile = filepath.Join(ProfileDir(name), "config.toml")
+	}
+	globalConfig = nil
+	return nil
+}
+
+func ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(ProfilesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && profileNamePattern.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func CreateProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if ProfileExists(name) {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+	return os.MkdirAll(ProfileDir(name), 0755)
+}
+
+func DeleteProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if err := os.RemoveAll(ProfileDir(name)); err != nil {
+		return err
+	}
+
+	state, err := LoadProfileState()
+	if err == nil && state.Current == name {
+		state.Current = ""
+		return SaveProfileState(state)
+	}
+	return nil
+}
+
+func LoadProfileState() (*ProfileState, error) {
+	state := &ProfileState{}
+	data, err := os.ReadFile(profileStateFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return &ProfileState{}, nil
+	}
+	return state, nil
+}
+
+func SaveProfileState(state *ProfileState) error {
+	if err := os.MkdirAll(ProfilesDir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(profileStateFile(), data, 0644)
+}
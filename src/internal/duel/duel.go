@@ -0,0 +1,389 @@
+// Package duel implements a same-terminal hot-seat duel where two players
+// take turns typing identical seeded texts.
+package duel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gti/src/internal"
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxRestarts is how many times a player may start their turn over with
+// Esc. Without a cap a player could keep retrying until they beat their
+// opponent's result.
+const maxRestarts = 1
+
+type Options struct {
+	Players [2]string
+	Rounds  int
+	Words   int
+	Seed    int64
+}
+
+type Round struct {
+	Seed    int64
+	Text    string
+	Results [2]session.Results
+	Winner  int
+}
+
+type Model struct {
+	config     *config.Config
+	opts       Options
+	rounds     []Round
+	current    Round
+	turn       int
+	restarts   int
+	phase      string
+	sess       *session.Session
+	calculator *session.ResultsCalculator
+	width      int
+	height     int
+}
+
+func NewModel(cfg *config.Config, opts Options) Model {
+	if opts.Rounds <= 0 {
+		opts.Rounds = 1
+	}
+	if opts.Words <= 0 {
+		opts.Words = 25
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	for i, name := range opts.Players {
+		if strings.TrimSpace(name) == "" {
+			opts.Players[i] = fmt.Sprintf("Player %d", i+1)
+		}
+	}
+
+	m := Model{
+		config:     cfg,
+		opts:       opts,
+		calculator: session.NewResultsCalculator(),
+	}
+	m.startRound()
+	return m
+}
+
+// Score ranks a single turn; raw speed is discounted by accuracy so that
+// mashing keys does not win a round.
+func Score(results session.Results) float64 {
+	return results.WPM * results.Accuracy / 100
+}
+
+func (m *Model) startRound() {
+	seed := m.opts.Seed + int64(len(m.rounds))
+	m.current = Round{
+		Seed:   seed,
+		Text:   internal.GenerateWordsSeeded(m.opts.Words, m.config.Language.Default, seed),
+		Winner: -1,
+	}
+	m.turn = 0
+	m.restarts = 0
+	m.phase = "ready"
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.EnterAltScreen
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.sess != nil {
+			m.sess.MarkLayoutDirty()
+		}
+		return m, nil
+	case session.TimerTickMsg:
+		if m.sess == nil || m.phase != "typing" {
+			return m, nil
+		}
+		return m, m.sess.UpdateTimer()
+	case session.SessionCompleteMsg:
+		return m.handleTurnComplete()
+	}
+	return m, nil
+}
+
+func (m *Model) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.phase {
+	case "ready":
+		switch key.String() {
+		case "enter":
+			m.phase = "typing"
+			m.sess = session.NewSessionWithDuel(m.config, m.current.Text)
+			m.sess.SetPlayer(m.opts.Players[m.turn])
+			return m, m.sess.Start()
+		case "q", "esc":
+			return m, tea.Quit
+		}
+	case "typing":
+		if key.String() == "esc" && m.restarts < maxRestarts {
+			m.restarts++
+			m.phase = "ready"
+			m.sess = nil
+			return m, nil
+		}
+		return m, m.sess.HandleInput(key)
+	case "round":
+		switch key.String() {
+		case "enter":
+			if m.matchDecided() {
+				m.phase = "final"
+				return m, nil
+			}
+			m.startRound()
+		case "q", "esc":
+			return m, tea.Quit
+		}
+	case "final":
+		switch key.String() {
+		case "enter", "q", "esc":
+			return m, tea.Quit
+		case "r":
+			m.rounds = nil
+			m.opts.Seed += int64(m.opts.Rounds)
+			m.startRound()
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) handleTurnComplete() (tea.Model, tea.Cmd) {
+	if m.phase != "typing" || m.sess == nil {
+		return m, nil
+	}
+
+	m.current.Results[m.turn] = m.calculator.CalculateResults(m.sess, m.sess.GetMode())
+	m.sess = nil
+
+	if m.turn == 0 {
+		m.turn = 1
+		m.restarts = 0
+		m.phase = "ready"
+		return m, nil
+	}
+
+	first, second := Score(m.current.Results[0]), Score(m.current.Results[1])
+	switch {
+	case first > second:
+		m.current.Winner = 0
+	case second > first:
+		m.current.Winner = 1
+	default:
+		m.current.Winner = -1
+	}
+	m.rounds = append(m.rounds, m.current)
+	m.phase = "round"
+	return m, nil
+}
+
+func (m Model) roundWins() [2]int {
+	var wins [2]int
+	for _, r := range m.rounds {
+		if r.Winner >= 0 {
+			wins[r.Winner]++
+		}
+	}
+	return wins
+}
+
+// matchDecided reports whether the best-of-N match is over, either because
+// all rounds were played or one player can no longer be caught.
+func (m Model) matchDecided() bool {
+	if len(m.rounds) >= m.opts.Rounds {
+		return true
+	}
+	needed := m.opts.Rounds/2 + 1
+	wins := m.roundWins()
+	return wins[0] >= needed || wins[1] >= needed
+}
+
+func (m Model) matchWinner() int {
+	wins := m.roundWins()
+	switch {
+	case wins[0] > wins[1]:
+		return 0
+	case wins[1] > wins[0]:
+		return 1
+	}
+
+	var totals [2]float64
+	for _, r := range m.rounds {
+		totals[0] += Score(r.Results[0])
+		totals[1] += Score(r.Results[1])
+	}
+	switch {
+	case totals[0] > totals[1]:
+		return 0
+	case totals[1] > totals[0]:
+		return 1
+	}
+	return -1
+}
+
+func (m Model) View() string {
+	if m.width < 40 || m.height < 10 {
+		return "Terminal too small. Please resize to at least 40x10.\nPress Ctrl+C to quit."
+	}
+
+	switch m.phase {
+	case "typing":
+		return lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+			Render(m.sess.View(m.width, m.height))
+	case "round":
+		return m.viewRound()
+	case "final":
+		return m.viewFinal()
+	default:
+		return m.viewReady()
+	}
+}
+
+func (m Model) viewReady() string {
+	restart := fmt.Sprintf("Esc restarts your turn (%d left).", maxRestarts-m.restarts)
+	if m.restarts >= maxRestarts {
+		restart = "No restarts left: this attempt counts."
+	}
+
+	content := fmt.Sprintf(`Duel - Round %d of %d
+
+%s, it's your turn!
+%s, look away from the screen.
+
+Both players type the same %d words.
+%s
+
+Press Enter to start, Q to quit`,
+		len(m.rounds)+1, m.opts.Rounds,
+		m.opts.Players[m.turn], m.opts.Players[1-m.turn],
+		m.opts.Words,
+		restart,
+	)
+	return m.renderDialog(content)
+}
+
+func (m Model) viewRound() string {
+	round := m.rounds[len(m.rounds)-1]
+	wins := m.roundWins()
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Round %d Results\n\n", len(m.rounds)))
+	b.WriteString(m.comparisonTable(round.Results))
+	b.WriteString("\n")
+	b.WriteString(m.winnerLine("Round winner", round.Winner))
+	b.WriteString(fmt.Sprintf("\nScore: %s %d - %d %s\n\n", m.opts.Players[0], wins[0], wins[1], m.opts.Players[1]))
+
+	if m.matchDecided() {
+		b.WriteString("Press Enter for the final results")
+	} else {
+		b.WriteString("Press Enter for the next round, Q to quit")
+	}
+	return m.renderDialog(b.String())
+}
+
+func (m Model) viewFinal() string {
+	wins := m.roundWins()
+
+	var totals [2]session.Results
+	for _, r := range m.rounds {
+		for i := range totals {
+			totals[i].WPM += r.Results[i].WPM
+			totals[i].Accuracy += r.Results[i].Accuracy
+			totals[i].CPM += r.Results[i].CPM
+			totals[i].Mistakes += r.Results[i].Mistakes
+			totals[i].Duration += r.Results[i].Duration
+		}
+	}
+	if n := float64(len(m.rounds)); n > 0 {
+		for i := range totals {
+			totals[i].WPM /= n
+			totals[i].Accuracy /= n
+			totals[i].CPM /= n
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Duel Complete - best of %d\n\n", m.opts.Rounds))
+	b.WriteString(m.comparisonTable(totals))
+	b.WriteString(fmt.Sprintf("%-12s %12d %12d\n", "Rounds won", wins[0], wins[1]))
+	b.WriteString("\n")
+	b.WriteString(m.winnerLine("Winner", m.matchWinner()))
+	b.WriteString("\nPress R for a rematch, Enter to exit")
+	return m.renderDialog(b.String())
+}
+
+func (m Model) comparisonTable(results [2]session.Results) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%-12s %12s %12s\n", "", truncate(m.opts.Players[0], 12), truncate(m.opts.Players[1], 12)))
+	b.WriteString(fmt.Sprintf("%-12s %12.1f %12.1f\n", "WPM", results[0].WPM, results[1].WPM))
+	b.WriteString(fmt.Sprintf("%-12s %11.1f%% %11.1f%%\n", "Accuracy", results[0].Accuracy, results[1].Accuracy))
+	b.WriteString(fmt.Sprintf("%-12s %12.1f %12.1f\n", "CPM", results[0].CPM, results[1].CPM))
+	b.WriteString(fmt.Sprintf("%-12s %12d %12d\n", "Mistakes", results[0].Mistakes, results[1].Mistakes))
+	b.WriteString(fmt.Sprintf("%-12s %11.2fs %11.2fs\n", "Time", results[0].Duration.Seconds(), results[1].Duration.Seconds()))
+	return b.String()
+}
+
+func (m Model) winnerLine(label string, winner int) string {
+	if winner < 0 {
+		return fmt.Sprintf("%s: draw\n", label)
+	}
+	return fmt.Sprintf("%s: %s\n", label, m.opts.Players[winner])
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func (m Model) renderDialog(content string) string {
+	styledContent := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(content)
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		BorderBackground(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Padding(1, 2).
+		Render(styledContent)
+
+	placedBox := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color(m.config.Theme.Colors.Background)))
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(placedBox)
+}
+
+func StartDuel(cfg *config.Config, opts Options) error {
+	model := NewModel(cfg, opts)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
@@ -100,7 +100,38 @@ func GenerateWordsDynamic(count int, language string) string {
 	return strings.Join(selected, " ")
 }
 
+func GenerateWordsSeeded(count int, language string, seed int64) string {
+	rng := rand.New(rand.NewSource(seed))
+	words := loadWords(language)
+	selected := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, words[rng.Intn(len(words))])
+	}
+	return strings.Join(selected, " ")
+}
+
 func IsLanguageSupported(language string) bool {
 	_, exists := languageFiles[language]
 	return exists
 }
+
+// GenerateWordsMinLength picks words of at least minLength letters, falling
+// back to the whole list for languages with too few long words.
+func GenerateWordsMinLength(count int, language string, minLength int) string {
+	words := loadWords(language)
+	var pool []string
+	for _, word := range words {
+		if len([]rune(word)) >= minLength {
+			pool = append(pool, word)
+		}
+	}
+	if len(pool) < count {
+		pool = words
+	}
+
+	selected := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, pool[rand.Intn(len(pool))])
+	}
+	return strings.Join(selected, " ")
+}
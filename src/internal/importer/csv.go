@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+// csvTable reads a CSV file with a header row, looking columns up by any of
+// several names regardless of case.
+type csvTable struct {
+	columns map[string]int
+	row     []string
+}
+
+func newCSVTable(header []string) *csvTable {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	return &csvTable{columns: columns}
+}
+
+func (t *csvTable) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := t.columns[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *csvTable) get(names ...string) string {
+	for _, name := range names {
+		if i, ok := t.columns[name]; ok && i < len(t.row) {
+			return strings.TrimSpace(t.row[i])
+		}
+	}
+	return ""
+}
+
+func (t *csvTable) number(names ...string) (float64, error) {
+	return parseNumber(t.get(names...))
+}
+
+func readCSV(reader io.Reader, visit func(table *csvTable, line int, result *Result)) (*Result, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	table := newCSVTable(header)
+
+	result := &Result{}
+	line := 1
+	for {
+		row, err := r.Read()
+		line++
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			result.skip(line, "%v", err)
+			continue
+		}
+		table.row = row
+		visit(table, line, result)
+	}
+	return result, nil
+}
+
+// parseCSV reads a generic spreadsheet export. Required columns are a
+// timestamp, a speed and a duration; everything else is optional:
+//
+//	timestamp|date, wpm, raw_wpm, net_wpm, duration|seconds, duration_ms,
+//	accuracy|acc, chars|characters, mistakes|errors, mode, language, id
+func parseCSV(reader io.Reader) (*Result, error) {
+	var missing error
+	repeats := make(map[string]int)
+	result, err := readCSV(reader, func(t *csvTable, line int, result *Result) {
+		if missing != nil {
+			return
+		}
+		if !t.has("timestamp", "date") || !t.has("wpm", "raw_wpm") || !t.has("duration", "seconds", "duration_ms") {
+			missing = fmt.Errorf("CSV needs timestamp, wpm and duration columns")
+			return
+		}
+
+		record, err := csvRecord(t, repeats)
+		if err != nil {
+			result.skip(line, "%v", err)
+			return
+		}
+		result.Records = append(result.Records, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if missing != nil {
+		return nil, missing
+	}
+	return result, nil
+}
+
+// csvRecord converts one row. Rows without an id are identified by their
+// timestamp, speed and duration instead, numbered in file order when several
+// rows share all three, since a timestamp may be just a date shared by every
+// session typed that day. repeats counts those rows so far.
+func csvRecord(t *csvTable, repeats map[string]int) (*session.SessionRecord, error) {
+	timestamp, err := parseTimestamp(t.get("timestamp", "date"))
+	if err != nil {
+		return nil, err
+	}
+
+	var duration time.Duration
+	if t.get("duration_ms") != "" {
+		ms, err := t.number("duration_ms")
+		if err != nil {
+			return nil, err
+		}
+		duration = time.Duration(ms) * time.Millisecond
+	} else {
+		seconds, err := t.number("duration", "seconds")
+		if err != nil {
+			return nil, err
+		}
+		duration = time.Duration(seconds * float64(time.Second))
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+
+	wpm, err := t.number("wpm")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := t.number("raw_wpm")
+	if err != nil {
+		return nil, err
+	}
+	net, err := t.number("net_wpm")
+	if err != nil {
+		return nil, err
+	}
+	if raw > 0 {
+		if net == 0 {
+			net = wpm
+		}
+		wpm = raw
+	}
+
+	accuracy, err := t.number("accuracy", "acc")
+	if err != nil {
+		return nil, err
+	}
+	mistakes, err := t.number("mistakes", "errors")
+	if err != nil {
+		return nil, err
+	}
+	chars, err := t.number("chars", "characters", "text_length")
+	if err != nil {
+		return nil, err
+	}
+	if chars == 0 {
+		chars = float64(charsFor(wpm, duration))
+	}
+	if accuracy == 0 && chars > 0 {
+		accuracy = session.CalculateAccuracy(int(chars), int(mistakes))
+	}
+
+	mode := t.get("mode")
+	if mode == "" {
+		mode = "practice"
+	}
+	id := t.get("id")
+	if id == "" {
+		key := fmt.Sprintf("%s/%.2f/%d", t.get("timestamp", "date"), wpm, duration.Milliseconds())
+		repeats[key]++
+		id = fmt.Sprintf("%s#%d", key, repeats[key])
+	}
+
+	return &session.SessionRecord{
+		Timestamp:  timestamp,
+		Mode:       mode,
+		Language:   strings.ToLower(t.get("language")),
+		TextLength: int(chars),
+		DurationMs: duration.Milliseconds(),
+		WPM:        wpm,
+		CPM:        wpm * 5,
+		NetWPM:     net,
+		Accuracy:   accuracy,
+		Mistakes:   int(mistakes),
+		Source:     SourceCSV,
+		SourceID:   id,
+	}, nil
+}
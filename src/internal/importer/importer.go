@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+const (
+	SourceMonkeytype = "monkeytype"
+	SourceKeybr      = "keybr"
+	SourceCSV        = "csv"
+)
+
+var Sources = []string{SourceMonkeytype, SourceKeybr, SourceCSV}
+
+// Result is what an import produced. Rows that could not be converted are
+// reported by line rather than failing the whole file.
+type Result struct {
+	Records []*session.SessionRecord
+	Skipped []string
+}
+
+func (r *Result) skip(line int, format string, args ...interface{}) {
+	r.Skipped = append(r.Skipped, fmt.Sprintf("line %d: %s", line, fmt.Sprintf(format, args...)))
+}
+
+// Parse converts an export from another trainer into session records tagged
+// with their source. Raw (gross) speed maps to WPM and the trainer's net
+// speed to NetWPM, matching how gti computes both.
+func Parse(source string, reader io.Reader) (*Result, error) {
+	switch source {
+	case SourceMonkeytype:
+		return parseMonkeytype(reader)
+	case SourceKeybr:
+		return parseKeybr(reader)
+	case SourceCSV:
+		return parseCSV(reader)
+	default:
+		return nil, fmt.Errorf("unknown import source '%s'. Valid options: monkeytype, keybr, csv", source)
+	}
+}
+
+// parseTimestamp accepts RFC 3339, common date-time layouts and Unix epochs
+// in seconds or milliseconds.
+func parseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		if n > 1e11 {
+			return time.UnixMilli(int64(n)), nil
+		}
+		return time.Unix(int64(n), 0), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised timestamp '%s'", value)
+}
+
+func parseNumber(value string) (float64, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "%")
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("invalid number '%s'", value)
+	}
+	return n, nil
+}
+
+// charsFor estimates the characters typed from a gross speed when an export
+// does not record them.
+func charsFor(wpm float64, duration time.Duration) int {
+	return int(math.Round(wpm * 5 * duration.Minutes()))
+}
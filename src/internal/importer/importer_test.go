@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMonkeytype(t *testing.T) {
+	input := strings.Join([]string{
+		"_id,wpm,acc,rawWpm,charStats,mode,mode2,testDuration,language,timestamp",
+		"a1,80,96.5,84,390;10;2;3,time,30,30,english,1767261600000",
+		"a2,60,98,62,300;2;0;1,quote,1,30,german,1767265200000",
+		"a3,70,97,71,,words,25,0,english,1767268800000",
+	}, "\n")
+
+	result, err := Parse(SourceMonkeytype, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Records) != 2 {
+		t.Fatalf("got %d records, want 2", len(result.Records))
+	}
+	if len(result.Skipped) != 1 || !strings.HasPrefix(result.Skipped[0], "line 4:") {
+		t.Errorf("skipped = %v, want line 4 for its zero duration", result.Skipped)
+	}
+
+	timed := result.Records[0]
+	if timed.Mode != "timed" || timed.DurationTargetMs != 30000 || timed.DurationMs != 30000 {
+		t.Errorf("timed test = %+v, want timed mode with a 30s target", timed)
+	}
+	if timed.WPM != 84 || timed.NetWPM != 80 || timed.Accuracy != 96.5 {
+		t.Errorf("timed speeds = wpm %v net %v acc %v, want raw 84, net 80, 96.5%%", timed.WPM, timed.NetWPM, timed.Accuracy)
+	}
+	if timed.TextLength != 402 || timed.Mistakes != 15 {
+		t.Errorf("char stats = %d chars %d mistakes, want 402 and 15", timed.TextLength, timed.Mistakes)
+	}
+	if !timed.Timestamp.Equal(time.UnixMilli(1767261600000)) || timed.Source != SourceMonkeytype || timed.SourceID != "a1" {
+		t.Errorf("identity = %v %s %s, want the export's timestamp and _id", timed.Timestamp, timed.Source, timed.SourceID)
+	}
+
+	quote := result.Records[1]
+	if quote.Mode != "quotes" || quote.TextSource != "quote" || quote.Language != "german" || quote.DurationTargetMs != 0 {
+		t.Errorf("quote test = %+v, want a german quote without a target", quote)
+	}
+}
+
+func TestParseRejectsWrongFormat(t *testing.T) {
+	generic := "timestamp,wpm,duration\n2026-01-01,50,30\n"
+
+	tests := []struct {
+		name   string
+		source string
+		input  string
+	}{
+		{name: "generic CSV as monkeytype", source: SourceMonkeytype, input: generic},
+		{name: "CSV as keybr", source: SourceKeybr, input: generic},
+		{name: "CSV without a speed", source: SourceCSV, input: "timestamp,duration\n2026-01-01,30\n"},
+		{name: "empty CSV", source: SourceCSV, input: ""},
+		{name: "unknown source", source: "typeracer", input: generic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.source, strings.NewReader(tt.input)); err == nil {
+				t.Errorf("Parse(%s) accepted %q", tt.source, tt.input)
+			}
+		})
+	}
+}
+
+func TestParseKeybr(t *testing.T) {
+	input := `[
+		{"layout":"en-us","textType":"generated","timeStamp":"2026-01-01T10:00:00.000Z","length":200,"time":40000,"errors":4,"speed":300},
+		{"layout":"de-de","textType":"book","timeStamp":"2026-01-01T10:05:00.000Z","length":150,"time":30000,"errors":0,"speed":300},
+		{"layout":"xx","textType":"generated","timeStamp":"2026-01-01T10:10:00.000Z","length":0,"time":0,"errors":0,"speed":0}
+	]`
+
+	result, err := Parse(SourceKeybr, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Records) != 2 || len(result.Skipped) != 1 {
+		t.Fatalf("got %d records and skipped %v, want 2 and the empty lesson", len(result.Records), result.Skipped)
+	}
+
+	lesson := result.Records[0]
+	if lesson.WPM != 60 || lesson.CPM != 300 || lesson.Language != "english" || lesson.Mode != "practice" {
+		t.Errorf("lesson = %+v, want 60 wpm english practice", lesson)
+	}
+	if lesson.Accuracy != 98 || lesson.CorrectedErrors != 4 || lesson.SourceID != "2026-01-01T10:00:00.000Z" {
+		t.Errorf("lesson errors = acc %v corrected %d id %s, want 98%%, 4 and the timestamp", lesson.Accuracy, lesson.CorrectedErrors, lesson.SourceID)
+	}
+
+	book := result.Records[1]
+	if book.Mode != "custom" || book.TextSource != "file:book" || book.Language != "german" {
+		t.Errorf("book lesson = %+v, want german custom text from file:book", book)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := strings.Join([]string{
+		"Date,WPM,Seconds,Accuracy,Mode,Language,id",
+		"2026-01-01,50,60,95%,timed,English,",
+		"2026-01-01 12:30,40,30,,,,row-2",
+		"2026-01-02,fast,30,,,,",
+		"2026-01-03,45,0,,,,",
+	}, "\n")
+
+	result, err := Parse(SourceCSV, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Records) != 2 {
+		t.Fatalf("got %d records, want 2", len(result.Records))
+	}
+	if len(result.Skipped) != 2 || !strings.HasPrefix(result.Skipped[0], "line 4:") || !strings.HasPrefix(result.Skipped[1], "line 5:") {
+		t.Errorf("skipped = %v, want lines 4 and 5", result.Skipped)
+	}
+
+	first := result.Records[0]
+	if first.Mode != "timed" || first.Language != "english" || first.DurationMs != 60000 || first.Accuracy != 95 {
+		t.Errorf("first row = %+v, want a 60s english timed session at 95%%", first)
+	}
+	if first.TextLength != 250 || first.SourceID == "" {
+		t.Errorf("first row = %d chars, id %q, want 250 estimated chars and a fallback id", first.TextLength, first.SourceID)
+	}
+
+	second := result.Records[1]
+	if second.Mode != "practice" || second.SourceID != "row-2" || second.Accuracy != 100 {
+		t.Errorf("second row = %+v, want practice with its own id", second)
+	}
+}
+
+func TestCSVIDsSurviveReordering(t *testing.T) {
+	header := "timestamp,wpm,duration"
+	rows := []string{
+		"2026-01-01,50,30",
+		"2026-01-01,50,30",
+		"2026-01-01,55,30",
+		"2026-01-02,50,30",
+	}
+	original := strings.Join(append([]string{header}, rows...), "\n")
+	// A later export has a new session on top and the old rows reversed.
+	shifted := strings.Join([]string{header, "2026-01-03,60,30", rows[3], rows[2], rows[1], rows[0]}, "\n")
+
+	ids := func(input string) map[string]bool {
+		result, err := Parse(SourceCSV, strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[string]bool)
+		for _, r := range result.Records {
+			if seen[r.SourceID] {
+				t.Errorf("id %q given to two rows", r.SourceID)
+			}
+			seen[r.SourceID] = true
+		}
+		return seen
+	}
+
+	before, after := ids(original), ids(shifted)
+	if len(before) != 4 || len(after) != 5 {
+		t.Fatalf("got %d and %d ids, want 4 and 5", len(before), len(after))
+	}
+	for id := range before {
+		if !after[id] {
+			t.Errorf("id %q changed when the export gained and reordered rows", id)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+// keybrResult is one lesson from the keybr.com data export.
+type keybrResult struct {
+	Layout    string  `json:"layout"`
+	TextType  string  `json:"textType"`
+	TimeStamp string  `json:"timeStamp"`
+	Length    int     `json:"length"`
+	Time      int64   `json:"time"`
+	Errors    int     `json:"errors"`
+	Speed     float64 `json:"speed"`
+}
+
+// keybr layouts are keyboard layouts such as "en-us"; the prefix is the
+// language of the words.
+var keybrLanguages = map[string]string{
+	"en": "english",
+	"de": "german",
+	"fr": "french",
+	"es": "spanish",
+	"it": "italian",
+	"pt": "portuguese",
+	"ru": "russian",
+}
+
+// parseKeybr reads the JSON array from Profile > Download data on keybr.
+// keybr measures speed in characters per minute and makes you fix every
+// error before moving on, so its errors count as corrected.
+func parseKeybr(reader io.Reader) (*Result, error) {
+	var lessons []keybrResult
+	if err := json.NewDecoder(reader).Decode(&lessons); err != nil {
+		return nil, fmt.Errorf("not a keybr export: %w", err)
+	}
+
+	result := &Result{}
+	for i, lesson := range lessons {
+		timestamp, err := parseTimestamp(lesson.TimeStamp)
+		if err != nil {
+			result.skip(i+1, "%v", err)
+			continue
+		}
+		if lesson.Time <= 0 || lesson.Length <= 0 {
+			result.skip(i+1, "empty lesson")
+			continue
+		}
+
+		duration := time.Duration(lesson.Time) * time.Millisecond
+		language := keybrLanguages[strings.SplitN(lesson.Layout, "-", 2)[0]]
+		if language == "" {
+			language = lesson.Layout
+		}
+		mode, source := "practice", "words"
+		if lesson.TextType == "book" || lesson.TextType == "custom" {
+			mode, source = "custom", "file:"+lesson.TextType
+		}
+
+		result.Records = append(result.Records, &session.SessionRecord{
+			Timestamp:       timestamp,
+			Mode:            mode,
+			Language:        language,
+			TextSource:      source,
+			TextLength:      lesson.Length,
+			DurationMs:      duration.Milliseconds(),
+			WPM:             lesson.Speed / 5,
+			CPM:             lesson.Speed,
+			NetWPM:          lesson.Speed / 5,
+			Accuracy:        session.CalculateAccuracy(lesson.Length, lesson.Errors),
+			Mistakes:        lesson.Errors,
+			CorrectedErrors: lesson.Errors,
+			Source:          SourceKeybr,
+			SourceID:        lesson.TimeStamp,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+var monkeytypeModes = map[string]string{
+	"time":   "timed",
+	"words":  "words",
+	"quote":  "quotes",
+	"custom": "custom",
+	"zen":    "practice",
+}
+
+// parseMonkeytype reads the CSV from Account > Export CSV on monkeytype.
+func parseMonkeytype(reader io.Reader) (*Result, error) {
+	var missing error
+	result, err := readCSV(reader, func(t *csvTable, line int, result *Result) {
+		if missing != nil {
+			return
+		}
+		if !t.has("_id") || !t.has("timestamp") || !t.has("wpm") || !t.has("testduration") {
+			missing = fmt.Errorf("not a monkeytype export: expected _id, timestamp, wpm and testDuration columns")
+			return
+		}
+
+		record, err := monkeytypeRecord(t)
+		if err != nil {
+			result.skip(line, "%v", err)
+			return
+		}
+		result.Records = append(result.Records, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if missing != nil {
+		return nil, missing
+	}
+	return result, nil
+}
+
+func monkeytypeRecord(t *csvTable) (*session.SessionRecord, error) {
+	timestamp, err := parseTimestamp(t.get("timestamp"))
+	if err != nil {
+		return nil, err
+	}
+	seconds, err := t.number("testduration")
+	if err != nil {
+		return nil, err
+	}
+	duration := time.Duration(seconds * float64(time.Second))
+	if duration <= 0 {
+		return nil, fmt.Errorf("test duration must be positive")
+	}
+
+	net, err := t.number("wpm")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := t.number("rawwpm")
+	if err != nil {
+		return nil, err
+	}
+	if raw == 0 {
+		raw = net
+	}
+	accuracy, err := t.number("acc")
+	if err != nil {
+		return nil, err
+	}
+
+	// charStats is "correct;incorrect;extra;missed".
+	chars, mistakes := charsFor(raw, duration), 0
+	if stats := strings.Split(t.get("charstats"), ";"); len(stats) == 4 {
+		var counts [4]int
+		for i, s := range stats {
+			counts[i], _ = strconv.Atoi(strings.TrimSpace(s))
+		}
+		chars = counts[0] + counts[1] + counts[2]
+		mistakes = counts[1] + counts[2] + counts[3]
+	}
+
+	mode, ok := monkeytypeModes[t.get("mode")]
+	if !ok {
+		mode = "practice"
+	}
+	language := strings.ToLower(t.get("language"))
+	if language == "" {
+		language = "english"
+	}
+	source := "words"
+	if mode == "quotes" {
+		source = "quote"
+	}
+	// mode2 is the length picked for the test: seconds for time mode.
+	var target time.Duration
+	if t.get("mode") == "time" {
+		if seconds, err := strconv.Atoi(t.get("mode2")); err == nil && seconds > 0 {
+			target = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return &session.SessionRecord{
+		Timestamp:         timestamp,
+		Mode:              mode,
+		Language:          language,
+		TextSource:        source,
+		DurationTargetMs:  target.Milliseconds(),
+		TextLength:        chars,
+		DurationMs:        duration.Milliseconds(),
+		WPM:               raw,
+		CPM:               raw * 5,
+		NetWPM:            net,
+		Accuracy:          accuracy,
+		Mistakes:          mistakes,
+		UncorrectedErrors: mistakes,
+		Source:            SourceMonkeytype,
+		SourceID:          t.get("_id"),
+	}, nil
+}
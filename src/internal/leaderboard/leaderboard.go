@@ -0,0 +1,298 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gti/src/internal/session"
+)
+
+type Window string
+
+const (
+	WindowWeek    Window = "week"
+	WindowMonth   Window = "month"
+	WindowAllTime Window = "all-time"
+)
+
+var Windows = []Window{WindowWeek, WindowMonth, WindowAllTime}
+
+// ModeAll ranks every mode together.
+const ModeAll = "all"
+
+func (w Window) Label() string {
+	switch w {
+	case WindowWeek:
+		return "This Week"
+	case WindowMonth:
+		return "This Month"
+	default:
+		return "All Time"
+	}
+}
+
+// Start returns the beginning of the window relative to now, or the zero
+// time for all time. Weeks start on Monday like the statistics view.
+func (w Window) Start(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch w {
+	case WindowWeek:
+		daysSinceMonday := int(now.Weekday() - time.Monday)
+		if daysSinceMonday < 0 {
+			daysSinceMonday += 7
+		}
+		return today.AddDate(0, 0, -daysSinceMonday)
+	case WindowMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+func ParseWindow(name string) (Window, error) {
+	for _, w := range Windows {
+		if string(w) == name {
+			return w, nil
+		}
+	}
+	return "", fmt.Errorf("invalid window '%s'. Valid options: week, month, all-time", name)
+}
+
+// Player holds the valid sessions found for one person across all of their
+// files in the shared directory.
+type Player struct {
+	Name    string                   `json:"name"`
+	Files   []string                 `json:"files"`
+	Records []*session.SessionRecord `json:"-"`
+	Skipped int                      `json:"skipped_sessions"`
+}
+
+type Entry struct {
+	Rank        int     `json:"rank"`
+	Player      string  `json:"player"`
+	Sessions    int     `json:"sessions"`
+	AvgWPM      float64 `json:"avg_wpm"`
+	BestWPM     float64 `json:"best_wpm"`
+	AvgAccuracy float64 `json:"avg_accuracy"`
+}
+
+// FileProblem is a file in the shared directory that could not be read in
+// full. Err is either why the whole file was skipped or a count of the
+// lines that were.
+type FileProblem struct {
+	Path string `json:"path"`
+	Err  string `json:"error"`
+}
+
+func (p FileProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Err)
+}
+
+// sessionKey identifies one player's session. The same session may appear
+// in a history file and an export, but two players' sessions never merge.
+type sessionKey struct {
+	player string
+	at     int64
+}
+
+type Board struct {
+	Mode    string  `json:"mode"`
+	Window  Window  `json:"window"`
+	Entries []Entry `json:"entries"`
+}
+
+// LoadDir reads every history file in dir. Files are matched to players by
+// name: "alice.jsonl" belongs to alice, as does "alice/history.jsonl".
+// Both raw history (.jsonl) and statistics exports (.json) are accepted.
+// Files that cannot be read, or only in part, are listed as problems rather
+// than failing the whole directory.
+func LoadDir(dir string) ([]*Player, []FileProblem, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	players := make(map[string]*Player)
+	seen := make(map[sessionKey]bool)
+	var problems []FileProblem
+
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && filepath.Dir(path) != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if ext != ".jsonl" && ext != ".json" {
+			return nil
+		}
+
+		records, unreadable, err := loadFile(path)
+		if err != nil {
+			problems = append(problems, FileProblem{Path: path, Err: err.Error()})
+			return nil
+		}
+		if unreadable > 0 {
+			problems = append(problems, FileProblem{Path: path, Err: fmt.Sprintf("%d unreadable lines skipped", unreadable)})
+		}
+
+		name := playerName(dir, path)
+		player, ok := players[name]
+		if !ok {
+			player = &Player{Name: name}
+			players[name] = player
+		}
+		player.Files = append(player.Files, path)
+
+		for _, r := range records {
+			key := sessionKey{player: name, at: r.Timestamp.UnixNano()}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if !session.IsValidRecord(r) {
+				player.Skipped++
+				continue
+			}
+			player.Records = append(player.Records, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]*Player, 0, len(players))
+	for _, p := range players {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, problems, nil
+}
+
+func playerName(root, path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	parent := filepath.Dir(path)
+	if base == "history" && parent != root {
+		return filepath.Base(parent)
+	}
+	return base
+}
+
+// loadFile reads a history file or statistics export, along with how many
+// lines of a history file could not be read.
+func loadFile(path string) ([]*session.SessionRecord, int, error) {
+	if filepath.Ext(path) == ".jsonl" {
+		records, report, err := session.LoadSessionRecordsWithReport(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		return records, len(report.Unreadable), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var export struct {
+		Sessions []*session.SessionRecord `json:"sessions"`
+	}
+	if err := json.Unmarshal(data, &export); err == nil && export.Sessions != nil {
+		return export.Sessions, 0, nil
+	}
+
+	var records []*session.SessionRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, 0, err
+	}
+	return records, 0, nil
+}
+
+// Modes lists every mode played by anyone, preceded by ModeAll.
+func Modes(players []*Player) []string {
+	set := make(map[string]bool)
+	for _, p := range players {
+		for _, r := range p.Records {
+			set[r.Mode] = true
+		}
+	}
+
+	modes := make([]string, 0, len(set))
+	for mode := range set {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	return append([]string{ModeAll}, modes...)
+}
+
+// Rank builds the board for one mode and window. Players are ordered by
+// average WPM so a single lucky run does not decide the table; best WPM
+// breaks ties.
+func Rank(players []*Player, mode string, window Window, now time.Time) Board {
+	start := window.Start(now)
+	board := Board{Mode: mode, Window: window, Entries: []Entry{}}
+
+	for _, p := range players {
+		entry := Entry{Player: p.Name}
+		var sumWPM, sumAcc float64
+		for _, r := range p.Records {
+			if mode != ModeAll && r.Mode != mode {
+				continue
+			}
+			if r.Timestamp.Before(start) {
+				continue
+			}
+			entry.Sessions++
+			sumWPM += r.WPM
+			sumAcc += r.Accuracy
+			if r.WPM > entry.BestWPM {
+				entry.BestWPM = r.WPM
+			}
+		}
+		if entry.Sessions == 0 {
+			continue
+		}
+		entry.AvgWPM = sumWPM / float64(entry.Sessions)
+		entry.AvgAccuracy = sumAcc / float64(entry.Sessions)
+		board.Entries = append(board.Entries, entry)
+	}
+
+	sort.SliceStable(board.Entries, func(i, j int) bool {
+		a, b := board.Entries[i], board.Entries[j]
+		if a.AvgWPM != b.AvgWPM {
+			return a.AvgWPM > b.AvgWPM
+		}
+		return a.BestWPM > b.BestWPM
+	})
+	for i := range board.Entries {
+		board.Entries[i].Rank = i + 1
+	}
+	return board
+}
+
+// RankAll builds a board for every mode and window combination.
+func RankAll(players []*Player, now time.Time) []Board {
+	var boards []Board
+	for _, mode := range Modes(players) {
+		for _, window := range Windows {
+			boards = append(boards, Rank(players, mode, window, now))
+		}
+	}
+	return boards
+}
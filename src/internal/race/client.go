@@ -0,0 +1,67 @@
+package race
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+)
+
+type Client struct {
+	conn net.Conn
+	enc  *json.Encoder
+	msgs chan Message
+	mu   sync.Mutex
+}
+
+func Dial(addr, name string, timeout time.Duration) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", NormalizeAddr(addr), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn: conn,
+		enc:  json.NewEncoder(conn),
+		msgs: make(chan Message, 64),
+	}
+
+	if err := c.Send(Message{Type: MsgJoin, Name: name}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *Client) Send(msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(msg)
+}
+
+// Messages yields every message from the host; it is closed when the
+// connection drops.
+func (c *Client) Messages() <-chan Message {
+	return c.msgs
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) readLoop() {
+	defer close(c.msgs)
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		c.msgs <- msg
+	}
+}
@@ -0,0 +1,395 @@
+package race
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gti/src/internal/config"
+	"gti/src/internal/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type serverMsg Message
+type disconnectedMsg struct{}
+type countdownTickMsg struct{}
+
+type Model struct {
+	config    *config.Config
+	client    *Client
+	server    *Server
+	hostAddr  string
+	sess      *session.Session
+	phase     string
+	playerID  int
+	name      string
+	text      string
+	language  string
+	players   []Player
+	countdown int
+	err       string
+	saved     bool
+	width     int
+	height    int
+}
+
+func NewModel(cfg *config.Config, client *Client, server *Server, hostAddr string) Model {
+	return Model{
+		config:   cfg,
+		client:   client,
+		server:   server,
+		hostAddr: hostAddr,
+		phase:    "lobby",
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		tea.EnterAltScreen,
+		m.waitForMessage(),
+	)
+}
+
+func (m Model) waitForMessage() tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-m.client.Messages()
+		if !ok {
+			return disconnectedMsg{}
+		}
+		return serverMsg(msg)
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.sess != nil {
+			m.sess.MarkLayoutDirty()
+		}
+		return m, nil
+	case serverMsg:
+		return m.handleServerMessage(Message(msg))
+	case disconnectedMsg:
+		if m.phase != "results" {
+			m.err = "connection to the race host was lost"
+		}
+		return m, nil
+	case countdownTickMsg:
+		m.countdown--
+		if m.countdown > 0 {
+			return m, countdownTick()
+		}
+		m.phase = "racing"
+		m.sess = session.NewSessionWithRace(m.config, m.text, m.language)
+		m.updateLanes()
+		return m, m.sess.Start()
+	case session.TimerTickMsg:
+		if m.sess == nil {
+			return m, nil
+		}
+		return m, m.sess.UpdateTimer()
+	case session.SessionCompleteMsg:
+		return m.handleFinish()
+	}
+	return m, nil
+}
+
+func (m *Model) handleServerMessage(msg Message) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case MsgWelcome:
+		m.playerID = msg.ID
+		m.name = msg.Name
+		m.text = msg.Text
+		m.language = msg.Language
+	case MsgLobby, MsgProgress:
+		m.players = msg.Players
+		m.updateLanes()
+	case MsgStart:
+		m.phase = "countdown"
+		m.countdown = msg.Countdown
+		return m, tea.Batch(m.waitForMessage(), countdownTick())
+	case MsgStandings:
+		m.players = msg.Players
+		m.phase = "results"
+		m.saveStandings()
+	case MsgError:
+		m.err = msg.Error
+	}
+	return m, m.waitForMessage()
+}
+
+func (m *Model) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.String() == "ctrl+c" {
+		return m, m.quit()
+	}
+
+	switch m.phase {
+	case "lobby":
+		switch key.String() {
+		case "enter":
+			if m.server != nil {
+				m.server.Start()
+			}
+		case "q", "esc":
+			return m, m.quit()
+		}
+		return m, nil
+	case "racing":
+		cmd := m.sess.HandleInput(key)
+		snapshot := m.sess.GetStatsSnapshot()
+		m.client.Send(Message{
+			Type:     MsgProgress,
+			Progress: snapshot.Progress,
+			WPM:      snapshot.WPM,
+			Accuracy: snapshot.Accuracy,
+		})
+		return m, cmd
+	case "results":
+		switch key.String() {
+		case "enter", "q", "esc":
+			return m, m.quit()
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) handleFinish() (tea.Model, tea.Cmd) {
+	if m.sess == nil || m.phase != "racing" {
+		return m, nil
+	}
+	m.phase = "finished"
+
+	results := session.NewResultsCalculator().CalculateResults(m.sess, m.sess.GetMode())
+	m.client.Send(Message{
+		Type:       MsgFinish,
+		Progress:   100,
+		WPM:        results.WPM,
+		Accuracy:   results.Accuracy,
+		DurationMs: results.Duration.Milliseconds(),
+	})
+	return m, nil
+}
+
+func (m *Model) quit() tea.Cmd {
+	m.client.Close()
+	if m.server != nil {
+		m.server.Close()
+	}
+	return tea.Quit
+}
+
+func (m *Model) updateLanes() {
+	if m.sess == nil {
+		return
+	}
+	lanes := make([]session.Lane, 0, len(m.players))
+	for _, p := range m.players {
+		name := p.Name
+		if !p.Connected {
+			name += " (left)"
+		}
+		lanes = append(lanes, session.Lane{
+			Name:     name,
+			Progress: p.Progress,
+			WPM:      p.WPM,
+			Finished: p.Finished,
+			Self:     p.ID == m.playerID,
+		})
+	}
+	m.sess.Lanes = lanes
+}
+
+func (m *Model) saveStandings() {
+	if m.saved {
+		return
+	}
+	m.saved = true
+
+	record := &RaceRecord{
+		Host:       m.hostAddr,
+		Player:     m.name,
+		TextLength: len(m.text),
+		Standings:  standingsFromPlayers(m.players),
+	}
+	if err := SaveRaceRecord(m.config, record); err != nil {
+		m.err = fmt.Sprintf("failed to save race record: %v", err)
+	}
+}
+
+func countdownTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return countdownTickMsg{}
+	})
+}
+
+func (m Model) View() string {
+	if m.width < 40 || m.height < 10 {
+		return "Terminal too small. Please resize to at least 40x10.\nPress Ctrl+C to quit."
+	}
+
+	switch m.phase {
+	case "racing", "finished":
+		return m.viewRace()
+	case "countdown":
+		return m.renderDialog(fmt.Sprintf("Race starting in\n\n%d", m.countdown))
+	case "results":
+		return m.viewResults()
+	default:
+		return m.viewLobby()
+	}
+}
+
+func (m Model) viewLobby() string {
+	var b strings.Builder
+	b.WriteString("Race Lobby\n\n")
+
+	if m.server != nil {
+		b.WriteString(fmt.Sprintf("Hosting on %s\n", m.server.Addr()))
+		if addrs := LocalAddresses(); len(addrs) > 0 {
+			b.WriteString(fmt.Sprintf("LAN: %s\n", strings.Join(addrs, ", ")))
+		}
+	} else {
+		b.WriteString(fmt.Sprintf("Connected to %s\n", m.hostAddr))
+	}
+	b.WriteString(fmt.Sprintf("Text: %d characters\n\n", len(m.text)))
+
+	b.WriteString("Players:\n")
+	for _, p := range m.players {
+		marker := "  "
+		if p.ID == m.playerID {
+			marker = "> "
+		}
+		b.WriteString(fmt.Sprintf("%s%s\n", marker, p.Name))
+	}
+
+	if m.err != "" {
+		b.WriteString("\n" + m.err + "\n")
+	}
+
+	if m.server != nil {
+		b.WriteString("\nPress Enter to start the race, Q to quit")
+	} else {
+		b.WriteString("\nWaiting for the host to start... (Q to quit)")
+	}
+
+	return m.renderDialog(b.String())
+}
+
+func (m Model) viewRace() string {
+	banner := m.err
+	if banner == "" && m.phase == "finished" {
+		banner = "Finished! Waiting for other racers..."
+	}
+
+	var content string
+	if banner != "" {
+		bannerLine := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.config.Theme.Colors.Accent)).
+			Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+			Width(m.width).
+			Align(lipgloss.Center).
+			Render(banner)
+		content = lipgloss.JoinVertical(lipgloss.Left, bannerLine, m.sess.View(m.width, m.height-1))
+	} else {
+		content = m.sess.View(m.width, m.height)
+	}
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(content)
+}
+
+func (m Model) viewResults() string {
+	var b strings.Builder
+	b.WriteString("Final Standings\n\n")
+
+	for _, p := range m.players {
+		place := "DNF"
+		if p.Finished {
+			place = fmt.Sprintf("#%d", p.Place)
+		}
+		marker := " "
+		if p.ID == m.playerID {
+			marker = "*"
+		}
+		b.WriteString(fmt.Sprintf("%s %-4s %-16s %6.1f wpm  %5.1f%%  %6.2fs\n",
+			marker, place, p.Name, p.WPM, p.Accuracy, float64(p.DurationMs)/1000))
+	}
+
+	if m.err != "" {
+		b.WriteString("\n" + m.err + "\n")
+	}
+	b.WriteString("\nPress Enter to exit")
+
+	return m.renderDialog(b.String())
+}
+
+func (m Model) renderDialog(content string) string {
+	styledContent := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(content)
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		BorderBackground(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Padding(1, 2).
+		Render(styledContent)
+
+	placedBox := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color(m.config.Theme.Colors.Background)))
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Render(placedBox)
+}
+
+type HostOptions struct {
+	Addr      string
+	Name      string
+	Countdown int
+	Text      string
+	Language  string
+}
+
+func StartHost(cfg *config.Config, opts HostOptions) error {
+	server := NewServer(opts.Text, opts.Language, opts.Countdown)
+	if err := server.Listen(opts.Addr); err != nil {
+		return fmt.Errorf("failed to start race server: %w", err)
+	}
+	defer server.Close()
+
+	client, err := Dial(server.Addr(), opts.Name, time.Duration(cfg.Network.TimeoutMs)*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("failed to join own race: %w", err)
+	}
+
+	model := NewModel(cfg, client, server, server.Addr())
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
+
+func StartJoin(cfg *config.Config, addr, name string) error {
+	client, err := Dial(addr, name, time.Duration(cfg.Network.TimeoutMs)*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("failed to connect to race host %s: %w", addr, err)
+	}
+
+	model := NewModel(cfg, client, nil, NormalizeAddr(addr))
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
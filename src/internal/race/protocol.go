@@ -0,0 +1,77 @@
+// Package race implements a small line-delimited JSON protocol over TCP for
+// racing other typists on a LAN or on localhost.
+package race
+
+import (
+	"net"
+	"strings"
+)
+
+const DefaultPort = "7878"
+
+const (
+	MsgJoin      = "join"
+	MsgWelcome   = "welcome"
+	MsgLobby     = "lobby"
+	MsgStart     = "start"
+	MsgProgress  = "progress"
+	MsgFinish    = "finish"
+	MsgStandings = "standings"
+	MsgError     = "error"
+)
+
+type Message struct {
+	Type       string   `json:"type"`
+	ID         int      `json:"id,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Text       string   `json:"text,omitempty"`
+	Language   string   `json:"language,omitempty"`
+	Countdown  int      `json:"countdown,omitempty"`
+	Progress   float64  `json:"progress,omitempty"`
+	WPM        float64  `json:"wpm,omitempty"`
+	Accuracy   float64  `json:"accuracy,omitempty"`
+	DurationMs int64    `json:"duration_ms,omitempty"`
+	Players    []Player `json:"players,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+type Player struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Progress   float64 `json:"progress"`
+	WPM        float64 `json:"wpm"`
+	Accuracy   float64 `json:"accuracy"`
+	DurationMs int64   `json:"duration_ms"`
+	Finished   bool    `json:"finished"`
+	Place      int     `json:"place"`
+	Connected  bool    `json:"connected"`
+}
+
+// NormalizeAddr appends the default port when addr has none.
+func NormalizeAddr(addr string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	if strings.HasPrefix(addr, ":") {
+		return addr
+	}
+	return net.JoinHostPort(addr, DefaultPort)
+}
+
+// LocalAddresses lists the non-loopback IPv4 addresses other players on the
+// LAN can use to reach this machine.
+func LocalAddresses() []string {
+	var addrs []string
+	ifaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return addrs
+	}
+	for _, a := range ifaceAddrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.To4() == nil {
+			continue
+		}
+		addrs = append(addrs, ipNet.IP.String())
+	}
+	return addrs
+}
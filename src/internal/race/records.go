@@ -0,0 +1,100 @@
+package race
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+type RaceRecord struct {
+	Timestamp  time.Time  `json:"timestamp"`
+	Host       string     `json:"host"`
+	Player     string     `json:"player"`
+	TextLength int        `json:"text_length"`
+	Standings  []Standing `json:"standings"`
+}
+
+type Standing struct {
+	Place      int     `json:"place"`
+	Name       string  `json:"name"`
+	WPM        float64 `json:"wpm"`
+	Accuracy   float64 `json:"accuracy"`
+	DurationMs int64   `json:"duration_ms"`
+	Finished   bool    `json:"finished"`
+}
+
+func recordsFile() string {
+	return filepath.Join(config.UserDataDir(), "races.jsonl")
+}
+
+func SaveRaceRecord(cfg *config.Config, record *RaceRecord) error {
+	if !cfg.History.Enabled {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(recordsFile()), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(recordsFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	record.Timestamp = time.Now()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(string(data) + "\n")
+	return err
+}
+
+func LoadRaceRecords(cfg *config.Config) ([]*RaceRecord, error) {
+	file, err := os.Open(recordsFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*RaceRecord{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*RaceRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record RaceRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, &record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Timestamp.After(records[j].Timestamp)
+	})
+
+	return records, scanner.Err()
+}
+
+func standingsFromPlayers(players []Player) []Standing {
+	standings := make([]Standing, 0, len(players))
+	for _, p := range players {
+		standings = append(standings, Standing{
+			Place:      p.Place,
+			Name:       p.Name,
+			WPM:        p.WPM,
+			Accuracy:   p.Accuracy,
+			DurationMs: p.DurationMs,
+			Finished:   p.Finished,
+		})
+	}
+	return standings
+}
@@ -0,0 +1,308 @@
+package race
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// writeTimeout bounds how long one slow client can hold up a broadcast.
+const writeTimeout = 2 * time.Second
+
+type Server struct {
+	text      string
+	language  string
+	countdown int
+	listener  net.Listener
+
+	mu       sync.Mutex
+	players  map[int]*serverPlayer
+	nextID   int
+	started  bool
+	finished int
+	done     bool
+	closed   bool
+}
+
+type serverPlayer struct {
+	Player
+	conn net.Conn
+	enc  *json.Encoder
+}
+
+func NewServer(text, language string, countdown int) *Server {
+	if countdown <= 0 {
+		countdown = 3
+	}
+	return &Server{
+		text:      text,
+		language:  language,
+		countdown: countdown,
+		players:   make(map[int]*serverPlayer),
+		nextID:    1,
+	}
+}
+
+func (s *Server) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	go s.acceptLoop()
+	return nil
+}
+
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Text() string {
+	return s.text
+}
+
+func (s *Server) PlayerCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.players)
+}
+
+// Start broadcasts the countdown to every connected player. Late joiners are
+// rejected once the race has started.
+func (s *Server) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+	s.broadcastLocked(Message{Type: MsgStart, Countdown: s.countdown})
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for _, p := range s.players {
+		p.conn.Close()
+	}
+	s.mu.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+func (s *Server) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	enc := json.NewEncoder(conn)
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	if !scanner.Scan() {
+		return
+	}
+	var join Message
+	if err := json.Unmarshal(scanner.Bytes(), &join); err != nil || join.Type != MsgJoin {
+		enc.Encode(Message{Type: MsgError, Error: "expected join message"})
+		return
+	}
+
+	player, err := s.addPlayer(conn, enc, join.Name)
+	if err != nil {
+		enc.Encode(Message{Type: MsgError, Error: err.Error()})
+		return
+	}
+	defer s.removePlayer(player.ID)
+
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		switch msg.Type {
+		case MsgProgress:
+			s.updateProgress(player.ID, msg)
+		case MsgFinish:
+			s.finishPlayer(player.ID, msg)
+		}
+	}
+}
+
+func (s *Server) addPlayer(conn net.Conn, enc *json.Encoder, name string) (*serverPlayer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return nil, errors.New("race already started")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "player"
+	}
+
+	player := &serverPlayer{
+		Player: Player{ID: s.nextID, Name: s.uniqueNameLocked(name), Connected: true},
+		conn:   conn,
+		enc:    enc,
+	}
+	s.players[player.ID] = player
+	s.nextID++
+
+	player.send(Message{Type: MsgWelcome, ID: player.ID, Name: player.Name, Text: s.text, Language: s.language})
+	s.broadcastLocked(Message{Type: MsgLobby, Players: s.snapshotLocked()})
+	return player, nil
+}
+
+func (s *Server) uniqueNameLocked(name string) string {
+	taken := func(candidate string) bool {
+		for _, p := range s.players {
+			if p.Name == candidate {
+				return true
+			}
+		}
+		return false
+	}
+	candidate := name
+	for i := 2; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
+func (s *Server) removePlayer(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	player, ok := s.players[id]
+	if !ok || s.closed {
+		return
+	}
+
+	if !s.started {
+		delete(s.players, id)
+		s.broadcastLocked(Message{Type: MsgLobby, Players: s.snapshotLocked()})
+		return
+	}
+
+	player.Connected = false
+	s.broadcastLocked(Message{Type: MsgProgress, Players: s.snapshotLocked()})
+	s.checkCompleteLocked()
+}
+
+func (s *Server) updateProgress(id int, msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	player, ok := s.players[id]
+	if !ok || !s.started || player.Finished {
+		return
+	}
+	player.Progress = msg.Progress
+	player.WPM = msg.WPM
+	player.Accuracy = msg.Accuracy
+	s.broadcastLocked(Message{Type: MsgProgress, Players: s.snapshotLocked()})
+}
+
+func (s *Server) finishPlayer(id int, msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	player, ok := s.players[id]
+	if !ok || !s.started || player.Finished {
+		return
+	}
+	s.finished++
+	player.Finished = true
+	player.Place = s.finished
+	player.Progress = 100
+	player.WPM = msg.WPM
+	player.Accuracy = msg.Accuracy
+	player.DurationMs = msg.DurationMs
+
+	s.broadcastLocked(Message{Type: MsgProgress, Players: s.snapshotLocked()})
+	s.checkCompleteLocked()
+}
+
+// checkCompleteLocked sends the final standings once every player still
+// connected has crossed the line.
+func (s *Server) checkCompleteLocked() {
+	if s.done {
+		return
+	}
+	for _, p := range s.players {
+		if p.Connected && !p.Finished {
+			return
+		}
+	}
+	s.done = true
+	s.broadcastLocked(Message{Type: MsgStandings, Players: s.snapshotLocked()})
+}
+
+// broadcastLocked sends msg to every connected player. A player whose
+// write fails or times out is marked disconnected and their connection
+// closed, which ends their read loop.
+func (s *Server) broadcastLocked(msg Message) {
+	for _, p := range s.players {
+		if p.Connected && !p.send(msg) {
+			p.Connected = false
+			p.conn.Close()
+		}
+	}
+}
+
+func (p *serverPlayer) send(msg Message) bool {
+	p.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return p.enc.Encode(msg) == nil
+}
+
+func (s *Server) snapshotLocked() []Player {
+	players := make([]Player, 0, len(s.players))
+	for _, p := range s.players {
+		players = append(players, p.Player)
+	}
+	SortStandings(players)
+	return players
+}
+
+// SortStandings orders finished players by place, then everyone else by
+// progress.
+func SortStandings(players []Player) {
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		if a.Finished != b.Finished {
+			return a.Finished
+		}
+		if a.Finished {
+			return a.Place < b.Place
+		}
+		if a.Progress != b.Progress {
+			return a.Progress > b.Progress
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,104 @@
+package race
+
+import (
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, c *Client, msgType string) Message {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case msg, ok := <-c.Messages():
+			if !ok {
+				t.Fatalf("connection closed waiting for %s", msgType)
+			}
+			if msg.Type == msgType {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", msgType)
+		}
+	}
+}
+
+func TestServerStandings(t *testing.T) {
+	server := NewServer("the quick brown fox", "english", 1)
+	if err := server.Listen("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	alice, err := Dial(server.Addr(), "alice", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer alice.Close()
+	welcome := waitFor(t, alice, MsgWelcome)
+	if welcome.Text != "the quick brown fox" || welcome.Language != "english" {
+		t.Fatalf("welcome = %+v, want the race text and language", welcome)
+	}
+
+	bob, err := Dial(server.Addr(), "bob", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bob.Close()
+	waitFor(t, bob, MsgWelcome)
+
+	server.Start()
+	waitFor(t, alice, MsgStart)
+	waitFor(t, bob, MsgStart)
+
+	if err := alice.Send(Message{Type: MsgProgress, Progress: 50, WPM: 60}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bob.Send(Message{Type: MsgFinish, WPM: 80, Accuracy: 100, DurationMs: 3000}); err != nil {
+		t.Fatal(err)
+	}
+	// Wait until the host has seen bob finish before alice does.
+	for {
+		msg := waitFor(t, alice, MsgProgress)
+		if len(msg.Players) == 2 && msg.Players[0].Finished {
+			break
+		}
+	}
+	if err := alice.Send(Message{Type: MsgFinish, WPM: 60, Accuracy: 95, DurationMs: 4000}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []*Client{alice, bob} {
+		standings := waitFor(t, c, MsgStandings)
+		if len(standings.Players) != 2 {
+			t.Fatalf("standings has %d players, want 2", len(standings.Players))
+		}
+		for i, want := range []struct {
+			name  string
+			place int
+		}{{"bob", 1}, {"alice", 2}} {
+			got := standings.Players[i]
+			if got.Name != want.name || got.Place != want.place || !got.Finished {
+				t.Errorf("standings[%d] = %+v, want %s in place %d", i, got, want.name, want.place)
+			}
+		}
+	}
+}
+
+func TestSortStandings(t *testing.T) {
+	players := []Player{
+		{ID: 1, Progress: 40},
+		{ID: 2, Finished: true, Place: 2},
+		{ID: 3, Progress: 80},
+		{ID: 4, Finished: true, Place: 1},
+		{ID: 5, Progress: 80},
+	}
+	SortStandings(players)
+
+	want := []int{4, 2, 3, 5, 1}
+	for i, id := range want {
+		if players[i].ID != id {
+			t.Fatalf("order = %v, want ids %v", players, want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+// PersonalBest is the fastest valid session in one category. Categories
+// split sessions by mode and language, and timed sessions also by their
+// time limit, so a 15 second sprint never competes with a 60 second test.
+type PersonalBest struct {
+	Category  string    `json:"category"`
+	Mode      string    `json:"mode"`
+	Language  string    `json:"language,omitempty"`
+	Length    string    `json:"length,omitempty"`
+	WPM       float64   `json:"wpm"`
+	Accuracy  float64   `json:"accuracy"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewBest describes a session that beat the category's personal best.
+// Previous is nil when it was the first valid session in the category.
+type NewBest struct {
+	Best     PersonalBest
+	Previous *PersonalBest
+}
+
+// Delta is how many WPM the new best gained over the previous one.
+func (n *NewBest) Delta() float64 {
+	if n.Previous == nil {
+		return 0
+	}
+	return n.Best.WPM - n.Previous.WPM
+}
+
+func personalBestsFile() string {
+	return filepath.Join(config.UserDataDir(), "personal_bests.json")
+}
+
+// BestCategory returns the category a record competes in, as a key and its
+// parts.
+func BestCategory(r *SessionRecord) (key, length string) {
+	if r.DurationTargetMs > 0 {
+		length = fmt.Sprintf("%ds", r.DurationTargetMs/1000)
+	}
+	key = r.Mode
+	if r.Language != "" {
+		key += " " + r.Language
+	}
+	if length != "" {
+		key += " " + length
+	}
+	return key, length
+}
+
+func bestFromRecord(r *SessionRecord) PersonalBest {
+	key, length := BestCategory(r)
+	return PersonalBest{
+		Category:  key,
+		Mode:      r.Mode,
+		Language:  r.Language,
+		Length:    length,
+		WPM:       r.WPM,
+		Accuracy:  r.Accuracy,
+		Timestamp: r.Timestamp,
+	}
+}
+
+// BuildPersonalBests finds the best valid session in every category. Ties
+// go to the earlier session, since it got there first.
+func BuildPersonalBests(records []*SessionRecord) map[string]PersonalBest {
+	bests := make(map[string]PersonalBest)
+	for _, r := range records {
+		if !IsValidRecord(r) {
+			continue
+		}
+		key, _ := BestCategory(r)
+		best, ok := bests[key]
+		if !ok || r.WPM > best.WPM || (r.WPM == best.WPM && r.Timestamp.Before(best.Timestamp)) {
+			bests[key] = bestFromRecord(r)
+		}
+	}
+	return bests
+}
+
+// SortedBests lists personal bests by mode, language and then length.
+func SortedBests(bests map[string]PersonalBest) []PersonalBest {
+	list := make([]PersonalBest, 0, len(bests))
+	for _, b := range bests {
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Mode != b.Mode {
+			return a.Mode < b.Mode
+		}
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		return a.Category < b.Category
+	})
+	return list
+}
+
+// LoadPersonalBests reads the personal best index. A missing index is
+// rebuilt from history and saved, so bests survive upgrades and deletions.
+func LoadPersonalBests(cfg *config.Config) (map[string]PersonalBest, error) {
+	data, err := os.ReadFile(personalBestsFile())
+	if err == nil {
+		bests := make(map[string]PersonalBest)
+		if err := json.Unmarshal(data, &bests); err == nil {
+			return bests, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	records, _, err := LoadHistory(cfg)
+	if err != nil {
+		return nil, err
+	}
+	bests := BuildPersonalBests(records)
+	return bests, savePersonalBests(bests)
+}
+
+func savePersonalBests(bests map[string]PersonalBest) error {
+	path := personalBestsFile()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(bests, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// invalidatePersonalBests drops the index so it is rebuilt from history the
+// next time it is read. It is called after sessions are removed or imported
+// and is best effort: a stale index only affects personal bests.
+func invalidatePersonalBests() {
+	os.Remove(personalBestsFile())
+}
+
+// updatePersonalBests checks a just saved record against the index and
+// returns the new best, or nil when the record did not beat it.
+func updatePersonalBests(cfg *config.Config, record *SessionRecord) (*NewBest, error) {
+	if !IsValidRecord(record) {
+		return nil, nil
+	}
+
+	path := personalBestsFile()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	lock, err := lockHistory(path, true)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	bests, err := LoadPersonalBests(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _ := BestCategory(record)
+	current := bestFromRecord(record)
+	previous, ok := bests[key]
+	if ok && previous.Timestamp.Equal(record.Timestamp) {
+		// Rebuilding the index just now already counted this record, so
+		// find the best it beat among the other sessions.
+		records, _, err := LoadHistory(cfg)
+		if err != nil {
+			return nil, err
+		}
+		others := make([]*SessionRecord, 0, len(records))
+		for _, r := range records {
+			if identity(r) != identity(record) {
+				others = append(others, r)
+			}
+		}
+		previous, ok = BuildPersonalBests(others)[key]
+	} else {
+		if ok && record.WPM <= previous.WPM {
+			return nil, nil
+		}
+		bests[key] = current
+		if err := savePersonalBests(bests); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return &NewBest{Best: current}, nil
+	}
+	return &NewBest{Best: current, Previous: &previous}, nil
+}
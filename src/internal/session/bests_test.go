@@ -0,0 +1,126 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+func TestBestCategory(t *testing.T) {
+	tests := []struct {
+		record SessionRecord
+		key    string
+		length string
+	}{
+		{SessionRecord{Mode: "timed", Language: "english", DurationTargetMs: 15000}, "timed english 15s", "15s"},
+		{SessionRecord{Mode: "timed", Language: "english", DurationTargetMs: 60000}, "timed english 60s", "60s"},
+		{SessionRecord{Mode: "words", Language: "german"}, "words german", ""},
+		{SessionRecord{Mode: "quote"}, "quote", ""},
+	}
+
+	for _, tt := range tests {
+		key, length := BestCategory(&tt.record)
+		if key != tt.key || length != tt.length {
+			t.Errorf("BestCategory(%+v) = %q, %q, want %q, %q", tt.record, key, length, tt.key, tt.length)
+		}
+	}
+}
+
+func TestBuildPersonalBests(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	record := func(minutes int, mode string, target int64, wpm float64) *SessionRecord {
+		r := testRecord(base.Add(time.Duration(minutes)*time.Minute), mode, wpm)
+		r.TextLength = 200
+		r.DurationTargetMs = target
+		return r
+	}
+	short := record(9, "timed", 15000, 150)
+	short.DurationMs, short.TextLength = 5000, 30
+
+	records := []*SessionRecord{
+		short,
+		record(8, "timed", 15000, 70),
+		record(7, "timed", 60000, 65),
+		record(6, "timed", 15000, 80),
+		record(5, "timed", 15000, 80),
+		record(4, "words", 0, 50),
+	}
+
+	got := SortedBests(BuildPersonalBests(records))
+	want := []struct {
+		category string
+		wpm      float64
+		at       time.Time
+	}{
+		{"timed english 15s", 80, records[4].Timestamp},
+		{"timed english 60s", 65, records[2].Timestamp},
+		{"words english", 50, records[5].Timestamp},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d bests, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Category != w.category || got[i].WPM != w.wpm || !got[i].Timestamp.Equal(w.at) {
+			t.Errorf("best %d = %s %.0f at %v, want %s %.0f at %v", i, got[i].Category, got[i].WPM, got[i].Timestamp, w.category, w.wpm, w.at)
+		}
+	}
+}
+
+func TestPersonalBestUpdates(t *testing.T) {
+	useTempDataDir(t)
+	cfg := config.DefaultConfig()
+	cfg.History = config.HistoryConfig{Enabled: true, Backend: BackendJSONL, File: filepath.Join(t.TempDir(), "history.jsonl")}
+
+	save := func(wpm float64) *NewBest {
+		t.Helper()
+		r := &SessionRecord{Mode: "timed", Language: "english", DurationTargetMs: 30000, DurationMs: 30000, TextLength: 200, WPM: wpm}
+		best, err := SaveSessionRecordWithBest(cfg, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return best
+	}
+
+	first := save(50)
+	if first == nil || first.Previous != nil || first.Best.WPM != 50 {
+		t.Fatalf("first session = %+v, want a new best with nothing before it", first)
+	}
+	if best := save(45); best != nil {
+		t.Errorf("slower session = %+v, want no new best", best)
+	}
+	faster := save(60)
+	if faster == nil || faster.Previous == nil || faster.Delta() != 10 {
+		t.Fatalf("faster session = %+v, want a new best 10 wpm up", faster)
+	}
+	if best := save(60); best != nil {
+		t.Errorf("tying session = %+v, want no new best", best)
+	}
+
+	// Deleting the best rebuilds the index from what is left.
+	store, _ := OpenStore(cfg.History)
+	records, _, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fastest []*SessionRecord
+	for _, r := range records {
+		if r.WPM == 60 {
+			fastest = append(fastest, r)
+		}
+	}
+	if _, err := DeleteRecords(store, fastest); err != nil {
+		t.Fatal(err)
+	}
+	bests, err := LoadPersonalBests(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best := bests["timed english 30s"]; best.WPM != 50 {
+		t.Errorf("best after deleting the fastest = %.0f, want 50", best.WPM)
+	}
+	if best := save(55); best == nil || best.Previous == nil || best.Previous.WPM != 50 {
+		t.Errorf("session after the deletion = %+v, want a new best over 50", best)
+	}
+}
@@ -0,0 +1,38 @@
+package session
+
+import (
+	"os"
+)
+
+// historyLock is an advisory lock on a sidecar file next to the history.
+// Locking the history itself would not survive atomic rewrites, which
+// replace the file a waiting writer already has open.
+type historyLock struct {
+	file *os.File
+}
+
+// lockHistory takes the lock for path. Only writers create the lock file,
+// so reading someone else's history leaves no trace next to it.
+func lockHistory(path string, exclusive bool) (*historyLock, error) {
+	flags := os.O_RDWR
+	if exclusive {
+		flags |= os.O_CREATE
+	}
+	file, err := os.OpenFile(path+".lock", flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file, exclusive); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &historyLock{file: file}, nil
+}
+
+func (l *historyLock) Unlock() error {
+	err := unlockFile(l.file)
+	if cerr := l.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package session
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+// goalRecentSessions is how many recent valid sessions make up the speed
+// measured against the WPM goal, the same number the statistics view uses
+// for its recent average.
+const goalRecentSessions = 5
+
+// GoalProgress is how far today's and this week's practice has come
+// towards the configured goals.
+type GoalProgress struct {
+	Goals         config.GoalsConfig
+	TodayMinutes  float64
+	TodaySessions int
+	WeekMinutes   float64
+	RecentWPM     float64
+	TargetDate    time.Time
+	Now           time.Time
+}
+
+// GoalItem is one goal with its progress, ready to display.
+type GoalItem struct {
+	Name    string  `json:"name"`
+	Current float64 `json:"current"`
+	Target  float64 `json:"target"`
+	Unit    string  `json:"unit"`
+	Done    bool    `json:"done"`
+	Note    string  `json:"note,omitempty"`
+}
+
+func (g GoalItem) Fraction() float64 {
+	if g.Target <= 0 {
+		return 0
+	}
+	return math.Min(g.Current/g.Target, 1)
+}
+
+// CalculateGoalProgress measures records, newest first, against goals.
+// Weeks start on Monday like the statistics view.
+func CalculateGoalProgress(goals config.GoalsConfig, records []*SessionRecord, now time.Time) GoalProgress {
+	progress := GoalProgress{Goals: goals, Now: now}
+	if goals.TargetDate != "" {
+		if date, err := time.ParseInLocation("2006-01-02", goals.TargetDate, now.Location()); err == nil {
+			progress.TargetDate = date
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	daysSinceMonday := int(now.Weekday() - time.Monday)
+	if daysSinceMonday < 0 {
+		daysSinceMonday += 7
+	}
+	monday := today.AddDate(0, 0, -daysSinceMonday)
+
+	recent := 0
+	var recentSum float64
+	for _, r := range records {
+		minutes := float64(r.DurationMs) / float64(time.Minute/time.Millisecond)
+		if !r.Timestamp.Before(today) {
+			progress.TodayMinutes += minutes
+			progress.TodaySessions++
+		}
+		if !r.Timestamp.Before(monday) {
+			progress.WeekMinutes += minutes
+		}
+		if recent < goalRecentSessions && IsValidRecord(r) {
+			recentSum += r.WPM
+			recent++
+		}
+	}
+	if recent > 0 {
+		progress.RecentWPM = recentSum / float64(recent)
+	}
+	return progress
+}
+
+// LoadGoalProgress measures the configured history against the configured
+// goals as of now.
+func LoadGoalProgress(cfg *config.Config) (GoalProgress, error) {
+	records, _, err := LoadHistory(cfg)
+	if err != nil {
+		return GoalProgress{Goals: cfg.Goals, Now: time.Now()}, err
+	}
+	return CalculateGoalProgress(cfg.Goals, records, time.Now()), nil
+}
+
+// Items lists the goals that are set, in a fixed order.
+func (p GoalProgress) Items() []GoalItem {
+	var items []GoalItem
+	if p.Goals.MinutesPerDay > 0 {
+		items = append(items, GoalItem{
+			Name:    "Minutes today",
+			Current: p.TodayMinutes,
+			Target:  float64(p.Goals.MinutesPerDay),
+			Unit:    "min",
+			Done:    p.TodayMinutes >= float64(p.Goals.MinutesPerDay),
+		})
+	}
+	if p.Goals.SessionsPerDay > 0 {
+		items = append(items, GoalItem{
+			Name:    "Sessions today",
+			Current: float64(p.TodaySessions),
+			Target:  float64(p.Goals.SessionsPerDay),
+			Unit:    "sessions",
+			Done:    p.TodaySessions >= p.Goals.SessionsPerDay,
+		})
+	}
+	if p.Goals.MinutesPerWeek > 0 {
+		items = append(items, GoalItem{
+			Name:    "Minutes this week",
+			Current: p.WeekMinutes,
+			Target:  float64(p.Goals.MinutesPerWeek),
+			Unit:    "min",
+			Done:    p.WeekMinutes >= float64(p.Goals.MinutesPerWeek),
+		})
+	}
+	if p.Goals.TargetWPM > 0 {
+		item := GoalItem{
+			Name:    "Recent WPM",
+			Current: p.RecentWPM,
+			Target:  p.Goals.TargetWPM,
+			Unit:    "wpm",
+			Done:    p.RecentWPM >= p.Goals.TargetWPM,
+		}
+		if !p.TargetDate.IsZero() {
+			item.Note = p.deadlineNote(item.Done)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (p GoalProgress) deadlineNote(done bool) string {
+	date := p.TargetDate.Format("2006-01-02")
+	today := time.Date(p.Now.Year(), p.Now.Month(), p.Now.Day(), 0, 0, 0, 0, p.Now.Location())
+	days := int(p.TargetDate.Sub(today).Hours() / 24)
+	switch {
+	case done:
+		return "by " + date
+	case days < 0:
+		return fmt.Sprintf("by %s, %d days overdue", date, -days)
+	case days == 0:
+		return fmt.Sprintf("by %s, today", date)
+	case days == 1:
+		return fmt.Sprintf("by %s, 1 day left", date)
+	default:
+		return fmt.Sprintf("by %s, %d days left", date, days)
+	}
+}
+
+// StatusText is the short goal summary for the status bar. elapsed is time
+// spent in the session under way, which counts towards today's minutes.
+func (p GoalProgress) StatusText(elapsed time.Duration) string {
+	switch {
+	case p.Goals.MinutesPerDay > 0:
+		return fmt.Sprintf("Goal: %.0f/%d min", p.TodayMinutes+elapsed.Minutes(), p.Goals.MinutesPerDay)
+	case p.Goals.SessionsPerDay > 0:
+		return fmt.Sprintf("Goal: %d/%d sessions", p.TodaySessions, p.Goals.SessionsPerDay)
+	case p.Goals.MinutesPerWeek > 0:
+		return fmt.Sprintf("Goal: %.0f/%d min this week", p.WeekMinutes+elapsed.Minutes(), p.Goals.MinutesPerWeek)
+	case p.Goals.TargetWPM > 0:
+		return fmt.Sprintf("Goal: %.0f/%.0f WPM", p.RecentWPM, p.Goals.TargetWPM)
+	}
+	return ""
+}
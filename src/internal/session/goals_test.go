@@ -0,0 +1,200 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+func goalRecord(at time.Time, minutes, wpm float64) *SessionRecord {
+	return &SessionRecord{
+		Timestamp:  at,
+		DurationMs: int64(minutes * 60000),
+		TextLength: 200,
+		WPM:        wpm,
+	}
+}
+
+func TestCalculateGoalProgress(t *testing.T) {
+	// 2026-01-07 is a Wednesday; its week started on Monday the 5th.
+	wednesday := time.Date(2026, 1, 7, 18, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, 1, 11, 18, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		records  []*SessionRecord
+		today    float64
+		sessions int
+		week     float64
+		wpm      float64
+	}{
+		{
+			name: "no history",
+			now:  wednesday,
+		},
+		{
+			name: "today, this week and last week",
+			now:  wednesday,
+			records: []*SessionRecord{
+				goalRecord(at(7, 9), 2, 50),
+				goalRecord(at(7, 0), 1, 40),
+				goalRecord(at(6, 12), 3, 60),
+				goalRecord(at(5, 0), 4, 30),
+				goalRecord(at(4, 23), 5, 30),
+			},
+			today:    3,
+			sessions: 2,
+			week:     10,
+			wpm:      42,
+		},
+		{
+			name:     "sunday still counts the week from monday",
+			now:      sunday,
+			records:  []*SessionRecord{goalRecord(at(11, 9), 1, 50), goalRecord(at(5, 9), 2, 50), goalRecord(at(4, 9), 4, 50)},
+			today:    1,
+			sessions: 1,
+			week:     3,
+			wpm:      50,
+		},
+		{
+			name: "recent speed uses the five newest valid sessions",
+			now:  wednesday,
+			records: []*SessionRecord{
+				goalRecord(at(7, 9), 0.1, 200),
+				goalRecord(at(7, 8), 1, 70),
+				goalRecord(at(7, 7), 1, 60),
+				goalRecord(at(7, 6), 1, 50),
+				goalRecord(at(7, 5), 1, 40),
+				goalRecord(at(7, 4), 1, 30),
+				goalRecord(at(7, 3), 1, 10),
+			},
+			today:    6.1,
+			sessions: 7,
+			week:     6.1,
+			wpm:      50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateGoalProgress(config.GoalsConfig{MinutesPerDay: 10}, tt.records, tt.now)
+			if !closeTo(got.TodayMinutes, tt.today) || got.TodaySessions != tt.sessions {
+				t.Errorf("today = %.2f min over %d sessions, want %.2f over %d", got.TodayMinutes, got.TodaySessions, tt.today, tt.sessions)
+			}
+			if !closeTo(got.WeekMinutes, tt.week) {
+				t.Errorf("week = %.2f min, want %.2f", got.WeekMinutes, tt.week)
+			}
+			if !closeTo(got.RecentWPM, tt.wpm) {
+				t.Errorf("recent wpm = %.2f, want %.2f", got.RecentWPM, tt.wpm)
+			}
+		})
+	}
+}
+
+func TestGoalItems(t *testing.T) {
+	now := time.Date(2026, 1, 7, 18, 0, 0, 0, time.UTC)
+	records := []*SessionRecord{goalRecord(now.Add(-time.Hour), 12, 55)}
+
+	tests := []struct {
+		name  string
+		goals config.GoalsConfig
+		names []string
+		done  []bool
+		note  string
+	}{
+		{name: "nothing set"},
+		{
+			name:  "every goal",
+			goals: config.GoalsConfig{MinutesPerDay: 10, SessionsPerDay: 2, MinutesPerWeek: 60, TargetWPM: 60},
+			names: []string{"Minutes today", "Sessions today", "Minutes this week", "Recent WPM"},
+			done:  []bool{true, false, false, false},
+		},
+		{
+			name:  "speed goal days left",
+			goals: config.GoalsConfig{TargetWPM: 60, TargetDate: "2026-01-10"},
+			names: []string{"Recent WPM"},
+			done:  []bool{false},
+			note:  "by 2026-01-10, 3 days left",
+		},
+		{
+			name:  "speed goal overdue",
+			goals: config.GoalsConfig{TargetWPM: 60, TargetDate: "2026-01-05"},
+			names: []string{"Recent WPM"},
+			done:  []bool{false},
+			note:  "by 2026-01-05, 2 days overdue",
+		},
+		{
+			name:  "speed goal reached",
+			goals: config.GoalsConfig{TargetWPM: 50, TargetDate: "2026-01-06"},
+			names: []string{"Recent WPM"},
+			done:  []bool{true},
+			note:  "by 2026-01-06",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := CalculateGoalProgress(tt.goals, records, now).Items()
+			if len(items) != len(tt.names) {
+				t.Fatalf("got %d items, want %d", len(items), len(tt.names))
+			}
+			for i, item := range items {
+				if item.Name != tt.names[i] || item.Done != tt.done[i] {
+					t.Errorf("item %d = %s done %v, want %s done %v", i, item.Name, item.Done, tt.names[i], tt.done[i])
+				}
+			}
+			if tt.note != "" && items[len(items)-1].Note != tt.note {
+				t.Errorf("note = %q, want %q", items[len(items)-1].Note, tt.note)
+			}
+		})
+	}
+}
+
+func TestSessionLoadsGoalsOnce(t *testing.T) {
+	useTempDataDir(t)
+	cfg := config.DefaultConfig()
+	cfg.History = config.HistoryConfig{Enabled: true, Backend: BackendJSONL, File: filepath.Join(t.TempDir(), "history.jsonl")}
+	cfg.Goals = config.GoalsConfig{SessionsPerDay: 5}
+
+	store, err := OpenStore(cfg.History)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append(testRecord(time.Now(), "words", 40)); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSessionWithRace(cfg, "some text", "english")
+	s.Start()
+	if s.goals == nil || s.goals.TodaySessions != 1 {
+		t.Fatalf("goals after the first start = %+v, want 1 session today", s.goals)
+	}
+
+	// Another instance saves a session; starting the next chunk must not
+	// read the history again.
+	if err := store.Append(testRecord(time.Now(), "words", 45)); err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	if s.goals.TodaySessions != 1 {
+		t.Errorf("goals reloaded on a later start: %d sessions today", s.goals.TodaySessions)
+	}
+
+	s.saveRecord(&SessionRecord{Mode: "race", DurationMs: 30000, TextLength: 100, WPM: 50})
+	if s.SaveError() != nil {
+		t.Fatal(s.SaveError())
+	}
+	if s.goals.TodaySessions != 3 {
+		t.Errorf("goals after saving = %d sessions today, want 3", s.goals.TodaySessions)
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
@@ -1,8 +1,6 @@
 package session
 
 import (
-	"bufio"
-	"encoding/json"
 	"os"
 	"sort"
 	"time"
@@ -11,6 +9,8 @@ import (
 )
 
 type SessionRecord struct {
+	SchemaVersion int `json:"schema_version"`
+
 	Timestamp   time.Time `json:"timestamp"`
 	Mode        string    `json:"mode"`
 	TextLength  int       `json:"text_length"`
@@ -20,7 +20,21 @@ type SessionRecord struct {
 	Accuracy    float64   `json:"accuracy"`
 	Mistakes    int       `json:"mistakes"`
 	Tier        string    `json:"tier,omitempty"`
+	Player      string    `json:"player,omitempty"`
 	QuoteAuthor string    `json:"quote_author,omitempty"`
+	Language    string    `json:"language,omitempty"`
+
+	// TextSource is where the text came from: "words" for generated words,
+	// "quote", "duel", or "file:<name>" for custom text, with the name left
+	// empty on sessions recorded before it was stored.
+	TextSource string `json:"text_source,omitempty"`
+	// DurationTargetMs is the time limit the session was played against;
+	// zero when it ran until the text was finished.
+	DurationTargetMs int64 `json:"duration_target_ms,omitempty"`
+
+	// Set on sessions imported from other typing trainers.
+	Source   string `json:"source,omitempty"`
+	SourceID string `json:"source_id,omitempty"`
 
 	NetWPM            float64 `json:"net_wpm,omitempty"`
 	AdjustedWPM       float64 `json:"adjusted_wpm,omitempty"`
@@ -28,64 +42,122 @@ type SessionRecord struct {
 	UncorrectedErrors int     `json:"uncorrected_errors,omitempty"`
 	BackspaceCount    int     `json:"backspace_count,omitempty"`
 	AvgWordLength     float64 `json:"avg_word_length,omitempty"`
+
+	// Typos classifies the typos left in the submitted text. Nil on
+	// sessions recorded before it was tracked and on imported sessions.
+	Typos *TypoSummary `json:"typos,omitempty"`
 }
 
 func SaveSessionRecord(cfg *config.Config, record *SessionRecord) error {
+	_, err := SaveSessionRecordWithBest(cfg, record)
+	return err
+}
+
+// SaveSessionRecordWithBest saves a record and updates the personal best
+// index, returning the new best when the session set one.
+func SaveSessionRecordWithBest(cfg *config.Config, record *SessionRecord) (*NewBest, error) {
 	if !cfg.History.Enabled {
-		return nil
+		return nil, nil
 	}
 
-	filePath := config.ExpandPath(cfg.History.File)
-	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	store, err := OpenStore(cfg.History)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer file.Close()
 
 	record.Timestamp = time.Now()
-	data, err := json.Marshal(record)
-	if err != nil {
-		return err
+	record.SchemaVersion = CurrentSchemaVersion
+	if err := store.Append(record); err != nil {
+		return nil, err
 	}
+	// The session is safely in history; a failure to update the index
+	// only costs the celebration, and the index is rebuilt when missing.
+	best, _ := updatePersonalBests(cfg, record)
+	return best, nil
+}
 
-	_, err = file.WriteString(string(data) + "\n")
-	return err
+// Sessions shorter than this, or over less text, are too noisy to count
+// towards averages and rankings.
+const (
+	MinValidDuration   = 15 * time.Second
+	MinValidTextLength = 60
+)
+
+func IsValidRecord(record *SessionRecord) bool {
+	d := time.Duration(record.DurationMs) * time.Millisecond
+	return d >= MinValidDuration && record.TextLength >= MinValidTextLength
+}
+
+func FilterValidSessions(records []*SessionRecord) []*SessionRecord {
+	valid := make([]*SessionRecord, 0, len(records))
+	for _, r := range records {
+		if IsValidRecord(r) {
+			valid = append(valid, r)
+		}
+	}
+	return valid
 }
 
 func LoadSessionRecords(cfg *config.Config) ([]*SessionRecord, error) {
+	records, _, err := LoadHistory(cfg)
+	return records, err
+}
+
+// LoadHistory is LoadSessionRecords with a report of what was read.
+func LoadHistory(cfg *config.Config) ([]*SessionRecord, *LoadReport, error) {
 	if !cfg.History.Enabled {
-		return nil, nil
+		return nil, &LoadReport{}, nil
+	}
+
+	store, err := OpenStore(cfg.History)
+	if err != nil {
+		return nil, nil, err
 	}
+	return store.Load()
+}
+
+// LoadSessionRecordsFile reads a history file, newest first.
+func LoadSessionRecordsFile(filePath string) ([]*SessionRecord, error) {
+	records, _, err := LoadSessionRecordsWithReport(filePath)
+	return records, err
+}
+
+// LoadSessionRecordsWithReport reads a history file, newest first, upgrading
+// older records to the current schema. Lines that cannot be read are listed
+// in the report rather than dropped silently.
+func LoadSessionRecordsWithReport(filePath string) ([]*SessionRecord, *LoadReport, error) {
+	// Reading without the lock is better than not reading at all, e.g. a
+	// teammate's history on a read-only share.
+	if lock, err := lockHistory(filePath, false); err == nil {
+		defer lock.Unlock()
+	}
+	return readHistoryFile(filePath)
+}
+
+// readHistoryFile does the work of LoadSessionRecordsWithReport for a
+// caller that already holds the history lock.
+func readHistoryFile(filePath string) ([]*SessionRecord, *LoadReport, error) {
+	report := &LoadReport{Path: filePath}
 
-	filePath := config.ExpandPath(cfg.History.File)
 	file, err := os.Open(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []*SessionRecord{}, nil
+			return []*SessionRecord{}, report, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	defer file.Close()
 
-	var records []*SessionRecord
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		var record SessionRecord
-		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
-			continue
-		}
-		records = append(records, &record)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	records, err := readRecords(file, report)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	sort.Slice(records, func(i, j int) bool {
+	sort.SliceStable(records, func(i, j int) bool {
 		return records[i].Timestamp.After(records[j].Timestamp)
 	})
 
-	return records, nil
+	return records, report, nil
 }
 
 func CalculateStreaks(validSessions []*SessionRecord) (int, int) {
@@ -104,15 +176,52 @@ func CalculateStreaks(validSessions []*SessionRecord) (int, int) {
 	return currentStreak, longestStreak
 }
 
-func extractUniqueDates(sessions []*SessionRecord) []string {
-	dateMap := make(map[string]bool)
+// DayKey is the calendar date a session belongs to, as YYYY-MM-DD.
+func DayKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// DayActivity totals the sessions played on one day.
+type DayActivity struct {
+	Date     string
+	Sessions int
+	Duration time.Duration
+	// Valid counts the sessions that pass IsValidRecord; only those count
+	// towards AvgWPM.
+	Valid  int
+	wpmSum float64
+}
+
+func (d *DayActivity) AvgWPM() float64 {
+	if d.Valid == 0 {
+		return 0
+	}
+	return d.wpmSum / float64(d.Valid)
+}
+
+// GroupByDay totals sessions per calendar day, keyed by DayKey.
+func GroupByDay(sessions []*SessionRecord) map[string]*DayActivity {
+	days := make(map[string]*DayActivity)
 	for _, session := range sessions {
-		date := session.Timestamp.Format("2006-01-02")
-		dateMap[date] = true
+		date := DayKey(session.Timestamp)
+		day, ok := days[date]
+		if !ok {
+			day = &DayActivity{Date: date}
+			days[date] = day
+		}
+		day.Sessions++
+		day.Duration += time.Duration(session.DurationMs) * time.Millisecond
+		if IsValidRecord(session) {
+			day.Valid++
+			day.wpmSum += session.WPM
+		}
 	}
+	return days
+}
 
+func extractUniqueDates(sessions []*SessionRecord) []string {
 	var dates []string
-	for date := range dateMap {
+	for date := range GroupByDay(sessions) {
 		dates = append(dates, date)
 	}
 	sort.Strings(dates)
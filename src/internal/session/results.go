@@ -10,6 +10,14 @@ type Results struct {
 	Accuracy float64
 	Mistakes int
 	Duration time.Duration
+
+	Timeline          []SecondStat
+	Consistency       float64
+	CorrectedErrors   int
+	UncorrectedErrors int
+	Backspaces        int
+	MistypedWords     []MistypedWord
+	Typos             TypoSummary
 }
 
 func CalculateWPM(totalChars int, duration time.Duration) float64 {
@@ -87,11 +95,21 @@ func (rc *ResultsCalculator) CalculateResults(session *Session, mode string) Res
 
 	accuracy := CalculateAccuracy(totalChars, mistakes)
 
+	timeline := BuildTimeline(session.Keystrokes(), session.GetDuration())
+
 	return Results{
 		WPM:      wpm,
 		CPM:      cpm,
 		Accuracy: accuracy,
 		Mistakes: mistakes,
 		Duration: session.GetDuration(),
+
+		Timeline:          timeline,
+		Consistency:       Consistency(timeline),
+		CorrectedErrors:   session.GetCorrectedErrors(),
+		UncorrectedErrors: session.GetUncorrectedErrors(),
+		Backspaces:        session.GetBackspaceCount(),
+		MistypedWords:     session.MistypedWords(),
+		Typos:             session.Typos(),
 	}
 }
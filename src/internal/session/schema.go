@@ -0,0 +1,316 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CurrentSchemaVersion is written to every new record. Records without a
+// version predate versioning and are treated as version 0.
+const CurrentSchemaVersion = 3
+
+const maxHistoryLineSize = 1024 * 1024
+
+// migrations[i] upgrades a record from version i to version i+1.
+var migrations = []func(*SessionRecord){
+	migrateV0,
+	migrateV1,
+	migrateV2,
+}
+
+// migrateV0 fills in the figures that early records did not store, so that
+// averages over old and new sessions compare like with like.
+func migrateV0(r *SessionRecord) {
+	duration := time.Duration(r.DurationMs) * time.Millisecond
+	if r.CPM == 0 && r.WPM > 0 {
+		r.CPM = r.WPM * 5
+	}
+	if r.NetWPM == 0 && duration > 0 {
+		r.NetWPM = math.Max(0, r.WPM-float64(r.UncorrectedErrors)/duration.Minutes())
+	}
+	if r.Mode == "" {
+		r.Mode = "practice"
+	}
+}
+
+// migrateV1 fills in the text source and time limit for sessions recorded
+// before they were stored. Timed sessions always stop exactly at their
+// limit, so the duration is the target. Imported sessions are left alone.
+func migrateV1(r *SessionRecord) {
+	if r.Source != "" || r.TextSource != "" {
+		return
+	}
+	switch r.Mode {
+	case "timed", "words":
+		r.TextSource = "words"
+		r.DurationTargetMs = r.DurationMs
+	case "practice", "survival", "arcade", "challenge", "challenge-boss":
+		r.TextSource = "words"
+	case "quote", "quotes":
+		r.TextSource = "quote"
+	case "race":
+		r.TextSource = "race"
+	case "custom", "custom-timed":
+		r.TextSource = "file"
+	}
+}
+
+// migrateV2 moves text sources to the form new records use, so filters and
+// breakdowns do not split one kind of session in two: custom text is
+// "file:<name>", with the name unknown for old sessions, race text is the
+// generated words it was, and duel turns were wrongly saved as races.
+func migrateV2(r *SessionRecord) {
+	switch {
+	case r.Source != "":
+	case r.TextSource == "file":
+		r.TextSource = "file:"
+	case r.Mode == "race" && r.TextSource == "race":
+		r.TextSource = "words"
+	case r.Mode == "duel" && r.TextSource == "race":
+		r.TextSource = "duel"
+	}
+}
+
+// MigrateRecord upgrades a record in place and reports whether it changed.
+// Records from a newer version of gti are left untouched.
+func MigrateRecord(r *SessionRecord) bool {
+	if r.SchemaVersion < 0 {
+		r.SchemaVersion = 0
+	}
+	if r.SchemaVersion >= CurrentSchemaVersion {
+		return false
+	}
+	for v := r.SchemaVersion; v < CurrentSchemaVersion; v++ {
+		migrations[v](r)
+	}
+	r.SchemaVersion = CurrentSchemaVersion
+	return true
+}
+
+type UnreadableLine struct {
+	Line  int    `json:"line"`
+	Text  string `json:"text"`
+	Error string `json:"error"`
+}
+
+// LoadReport describes what happened while reading a history file.
+type LoadReport struct {
+	Path       string           `json:"path"`
+	Loaded     int              `json:"loaded"`
+	Migrated   int              `json:"migrated"`
+	Newer      int              `json:"newer"`
+	Unreadable []UnreadableLine `json:"unreadable,omitempty"`
+}
+
+func (r *LoadReport) HasProblems() bool {
+	return r != nil && len(r.Unreadable) > 0
+}
+
+func (r *LoadReport) Summary() string {
+	s := fmt.Sprintf("%d sessions read, %d upgraded from an older format", r.Loaded, r.Migrated)
+	if r.Newer > 0 {
+		s += fmt.Sprintf(", %d from a newer version of gti", r.Newer)
+	}
+	if len(r.Unreadable) > 0 {
+		s += fmt.Sprintf(", %d unreadable lines", len(r.Unreadable))
+	}
+	return s
+}
+
+// readRecords parses JSONL in file order, migrating records as it goes.
+func readRecords(reader io.Reader, report *LoadReport) ([]*SessionRecord, error) {
+	var records []*SessionRecord
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxHistoryLineSize)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var record SessionRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			report.Unreadable = append(report.Unreadable, UnreadableLine{
+				Line:  lineNumber,
+				Text:  string(line),
+				Error: err.Error(),
+			})
+			continue
+		}
+		if record.SchemaVersion > CurrentSchemaVersion {
+			report.Newer++
+		}
+		if MigrateRecord(&record) {
+			report.Migrated++
+		}
+		records = append(records, &record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	report.Loaded = len(records)
+	return records, nil
+}
+
+// MigrateResult describes a rewrite done by MigrateHistoryFile.
+type MigrateResult struct {
+	Report       *LoadReport
+	BackupPath   string
+	RejectedPath string
+	Rewritten    bool
+}
+
+// MigrateHistoryFile upgrades every record in a history file to the current
+// schema. The original is kept as a timestamped backup and the new file is
+// swapped in with a rename, so an interrupted run never leaves a half-written
+// history. Unreadable lines are moved to a .rejected file next to it.
+func MigrateHistoryFile(filePath string, dryRun bool) (*MigrateResult, error) {
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, err
+	}
+	lock, err := lockHistory(filePath, true)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	report := &LoadReport{Path: filePath}
+	records, err := readRecords(file, report)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrateResult{Report: report}
+	if dryRun || (report.Migrated == 0 && len(report.Unreadable) == 0) {
+		return result, nil
+	}
+
+	stamp := time.Now().Format("20060102-150405")
+	result.BackupPath = fmt.Sprintf("%s.%s.bak", filePath, stamp)
+	if err := copyFile(filePath, result.BackupPath); err != nil {
+		return nil, fmt.Errorf("failed to back up history: %w", err)
+	}
+
+	if len(report.Unreadable) > 0 {
+		result.RejectedPath = fmt.Sprintf("%s.%s.rejected", filePath, stamp)
+		var buf bytes.Buffer
+		for _, u := range report.Unreadable {
+			buf.WriteString(u.Text + "\n")
+		}
+		if err := os.WriteFile(result.RejectedPath, buf.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to save unreadable lines: %w", err)
+		}
+	}
+
+	if err := WriteRecordsAtomic(filePath, records); err != nil {
+		return nil, err
+	}
+	result.Rewritten = true
+	return result, nil
+}
+
+// MigrateHistory upgrades every record in a store. JSONL files are rewritten
+// with MigrateHistoryFile; databases are backed up and rebuilt.
+func MigrateHistory(store Store, dryRun bool) (*MigrateResult, error) {
+	if _, ok := store.(*JSONLStore); ok {
+		return MigrateHistoryFile(store.Path(), dryRun)
+	}
+
+	if _, err := os.Stat(store.Path()); err != nil {
+		return nil, err
+	}
+	records, report, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrateResult{Report: report}
+	if dryRun || report.Migrated == 0 {
+		return result, nil
+	}
+
+	result.BackupPath = fmt.Sprintf("%s.%s.bak", store.Path(), time.Now().Format("20060102-150405"))
+	if err := copyFile(store.Path(), result.BackupPath); err != nil {
+		return nil, fmt.Errorf("failed to back up history: %w", err)
+	}
+	if err := store.Replace(records); err != nil {
+		return nil, err
+	}
+	result.Rewritten = true
+	return result, nil
+}
+
+// WriteRecordsAtomic replaces a history file with the given records in the
+// given order, writing to a temporary file first and renaming it into place.
+func WriteRecordsAtomic(filePath string, records []*SessionRecord) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	writer := bufio.NewWriter(tmp)
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		writer.Write(data)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if info, err := os.Stat(filePath); err == nil {
+		os.Chmod(tmpPath, info.Mode().Perm())
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
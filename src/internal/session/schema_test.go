@@ -0,0 +1,129 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrateRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		in   SessionRecord
+		want SessionRecord
+	}{
+		{
+			name: "v0 fills in speed figures and mode",
+			in:   SessionRecord{WPM: 60, DurationMs: 60000, UncorrectedErrors: 6},
+			want: SessionRecord{WPM: 60, CPM: 300, NetWPM: 54, DurationMs: 60000, UncorrectedErrors: 6, Mode: "practice", TextSource: "words"},
+		},
+		{
+			name: "v0 timed session gets its limit",
+			in:   SessionRecord{Mode: "timed", WPM: 50, CPM: 250, NetWPM: 50, DurationMs: 30000},
+			want: SessionRecord{Mode: "timed", WPM: 50, CPM: 250, NetWPM: 50, DurationMs: 30000, TextSource: "words", DurationTargetMs: 30000},
+		},
+		{
+			name: "v0 custom text uses the file form",
+			in:   SessionRecord{Mode: "custom", WPM: 50, CPM: 250, NetWPM: 50},
+			want: SessionRecord{Mode: "custom", WPM: 50, CPM: 250, NetWPM: 50, TextSource: "file:"},
+		},
+		{
+			name: "v1 quote",
+			in:   SessionRecord{SchemaVersion: 1, Mode: "quote"},
+			want: SessionRecord{Mode: "quote", TextSource: "quote"},
+		},
+		{
+			name: "v1 race is generated words",
+			in:   SessionRecord{SchemaVersion: 1, Mode: "race"},
+			want: SessionRecord{Mode: "race", TextSource: "words"},
+		},
+		{
+			name: "v2 custom text moves to the file form",
+			in:   SessionRecord{SchemaVersion: 2, Mode: "custom", TextSource: "file"},
+			want: SessionRecord{Mode: "custom", TextSource: "file:"},
+		},
+		{
+			name: "v2 duel saved as a race",
+			in:   SessionRecord{SchemaVersion: 2, Mode: "duel", TextSource: "race"},
+			want: SessionRecord{Mode: "duel", TextSource: "duel"},
+		},
+		{
+			name: "v2 named file is kept",
+			in:   SessionRecord{SchemaVersion: 2, Mode: "custom", TextSource: "file:notes.txt"},
+			want: SessionRecord{Mode: "custom", TextSource: "file:notes.txt"},
+		},
+		{
+			name: "imported sessions keep their text source",
+			in:   SessionRecord{SchemaVersion: 1, Mode: "custom", Source: "csv"},
+			want: SessionRecord{Mode: "custom", Source: "csv"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.in
+			if !MigrateRecord(&got) {
+				t.Fatal("MigrateRecord reported no change")
+			}
+			tt.want.SchemaVersion = CurrentSchemaVersion
+			if got != tt.want {
+				t.Errorf("MigrateRecord = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrateRecordLeavesCurrentAndNewer(t *testing.T) {
+	for _, version := range []int{CurrentSchemaVersion, CurrentSchemaVersion + 1} {
+		r := SessionRecord{SchemaVersion: version, Mode: "custom", TextSource: "file"}
+		if MigrateRecord(&r) {
+			t.Errorf("version %d: MigrateRecord changed %+v", version, r)
+		}
+		if r.TextSource != "file" {
+			t.Errorf("version %d: text source = %q, want it untouched", version, r.TextSource)
+		}
+	}
+}
+
+func TestReadRecords(t *testing.T) {
+	input := strings.Join([]string{
+		`{"timestamp":"2026-01-01T10:00:00Z","mode":"custom","wpm":40}`,
+		``,
+		`{"schema_version":1,"timestamp":"2026-01-02T10:00:00Z","mode":"race","wpm":50}`,
+		`{"timestamp":"2026-01-03T10:00:00Z","wpm":`,
+		`not json`,
+		`{"schema_version":3,"timestamp":"2026-01-04T10:00:00Z","mode":"timed","text_source":"words","wpm":60}`,
+		`{"schema_version":99,"timestamp":"2026-01-05T10:00:00Z","mode":"timed","wpm":70}`,
+	}, "\n")
+
+	report := &LoadReport{}
+	records, err := readRecords(strings.NewReader(input), report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Loaded != 4 || len(records) != 4 {
+		t.Fatalf("loaded %d records (report %d), want 4", len(records), report.Loaded)
+	}
+	if report.Migrated != 2 {
+		t.Errorf("migrated = %d, want 2", report.Migrated)
+	}
+	if report.Newer != 1 {
+		t.Errorf("newer = %d, want 1", report.Newer)
+	}
+	if len(report.Unreadable) != 2 {
+		t.Fatalf("unreadable = %+v, want 2 lines", report.Unreadable)
+	}
+	if report.Unreadable[0].Line != 4 || report.Unreadable[1].Line != 5 {
+		t.Errorf("unreadable lines = %d and %d, want 4 and 5", report.Unreadable[0].Line, report.Unreadable[1].Line)
+	}
+
+	if records[0].TextSource != "file:" || records[0].SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("v0 custom record = %+v, want text source file: at the current version", records[0])
+	}
+	if records[1].TextSource != "words" {
+		t.Errorf("v1 race text source = %q, want words", records[1].TextSource)
+	}
+	if records[3].SchemaVersion != 99 {
+		t.Errorf("newer record version = %d, want it left at 99", records[3].SchemaVersion)
+	}
+}
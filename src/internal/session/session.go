@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -150,8 +151,11 @@ type Session struct {
 	config                *config.Config
 	mode                  string
 	tier                  string
+	player                string
 	text                  string
 	author                string
+	language              string
+	source                string
 	userInput             string
 	position              int
 	mistakes              int
@@ -175,12 +179,26 @@ type Session struct {
 	ttsUnavailableMessage string
 	RemainingTimeDisplay  int
 	ExternalMistakes      int
+	Lanes                 []Lane
+
+	// Challenge boss modifiers.
+	HideErrors  bool
+	MaskPending bool
+	NoBackspace bool
 
 	backspaceCount    int
 	correctedErrors   int
 	uncorrectedErrors int
 	correctChars      int
 	avgWordLength     float64
+	keystrokes        []Keystroke
+	mistypedWords     []MistypedWord
+	typos             TypoSummary
+
+	saveErr     error
+	newBest     *NewBest
+	goals       *GoalProgress
+	goalsLoaded bool
 }
 
 func NewSession(cfg *config.Config, mode string) *Session {
@@ -205,6 +223,12 @@ func NewSession(cfg *config.Config, mode string) *Session {
 		mode:      mode,
 		text:      text,
 		timeLimit: timeLimit,
+		language:  cfg.Language.Default,
+		source:    "words",
+	}
+	if mode == "quote" {
+		session.language = "english"
+		session.source = "quote"
 	}
 	session.calculateAvgWordLength()
 	return session
@@ -220,6 +244,7 @@ func NewSessionWithCustomText(cfg *config.Config, mode, file string, start int)
 		text:       text,
 		allChunks:  paragraphs,
 		chunkIndex: start - 1,
+		source:     fileSource(file),
 	}
 }
 
@@ -229,6 +254,8 @@ func NewSessionWithTimed(cfg *config.Config, seconds int) *Session {
 		mode:      "timed",
 		text:      internal.GenerateWordsDynamic(10, cfg.Language.Default),
 		timeLimit: time.Duration(seconds) * time.Second,
+		language:  cfg.Language.Default,
+		source:    "words",
 	}
 }
 
@@ -243,6 +270,7 @@ func NewSessionWithCustomTimed(cfg *config.Config, file string, start int, secon
 		allChunks:  paragraphs,
 		chunkIndex: start - 1,
 		timeLimit:  time.Duration(seconds) * time.Second,
+		source:     fileSource(file),
 	}
 }
 
@@ -251,25 +279,55 @@ func NewSessionWithChallenge(cfg *config.Config, tier string) *Session {
 		config: cfg,
 		mode:   "challenge",
 		tier:   tier,
+		source: "words",
 	}
 }
 
+// NewSessionWithRace types the host's race text, generated from words in
+// the host's language.
+func NewSessionWithRace(cfg *config.Config, text, language string) *Session {
+	session := &Session{
+		config:   cfg,
+		mode:     "race",
+		text:     text,
+		language: language,
+		source:   "words",
+	}
+	session.calculateAvgWordLength()
+	return session
+}
+
+func NewSessionWithDuel(cfg *config.Config, text string) *Session {
+	session := &Session{
+		config: cfg,
+		mode:   "duel",
+		text:   text,
+		source: "duel",
+	}
+	session.calculateAvgWordLength()
+	return session
+}
+
 func NewSessionWithQuotes(cfg *config.Config, quoteList []Quote) *Session {
 	if len(quoteList) == 0 {
 		return &Session{
-			config: cfg,
-			mode:   "quote",
-			text:   config.DefaultPracticeText,
-			author: "Unknown",
+			config:   cfg,
+			mode:     "quote",
+			text:     config.DefaultPracticeText,
+			author:   "Unknown",
+			language: "english",
+			source:   "quote",
 		}
 	}
 
 	if len(quoteList) == 1 {
 		return &Session{
-			config: cfg,
-			mode:   "quote",
-			text:   quoteList[0].Text,
-			author: quoteList[0].Author,
+			config:   cfg,
+			mode:     "quote",
+			text:     quoteList[0].Text,
+			author:   quoteList[0].Author,
+			language: "english",
+			source:   "quote",
 		}
 	}
 
@@ -285,6 +343,8 @@ func NewSessionWithQuotes(cfg *config.Config, quoteList []Quote) *Session {
 		allChunks:  quoteTexts,
 		chunkIndex: 0,
 		author:     quoteList[0].Author,
+		language:   "english",
+		source:     "quote",
 	}
 }
 
@@ -313,9 +373,16 @@ func NewSessionWithChunkLimit(cfg *config.Config, maxChunks int) *Session {
 		isGroupMode:       isGroupMode,
 		pageSize:          pageSize,
 		currentPageChunks: currentPageChunks,
+		language:          cfg.Language.Default,
+		source:            "words",
 	}
 }
 
+// fileSource names a custom text file for history without its directory.
+func fileSource(file string) string {
+	return "file:" + filepath.Base(file)
+}
+
 func loadTextFromFile(file string) (string, error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
@@ -426,9 +493,26 @@ func ttsAvailable() bool {
 func (s *Session) Start() tea.Cmd {
 	s.startTime = time.Now()
 	s.running = true
+	if !s.goalsLoaded {
+		s.loadGoals()
+	}
 	return s.tickTimer()
 }
 
+// loadGoals measures practice so far today against the configured goals,
+// for the status bar. It reads the whole history, so it runs on the first
+// Start and after each save rather than for every chunk.
+func (s *Session) loadGoals() {
+	s.goals = nil
+	s.goalsLoaded = true
+	if !s.config.Goals.IsSet() || !s.config.History.Enabled {
+		return
+	}
+	if progress, err := LoadGoalProgress(s.config); err == nil {
+		s.goals = &progress
+	}
+}
+
 func (s *Session) Restart() tea.Cmd {
 	s.userInput = ""
 	s.position = 0
@@ -439,6 +523,15 @@ func (s *Session) Restart() tea.Cmd {
 	s.chunkIndex = 0
 	s.duration = 0
 	s.completed = false
+	s.backspaceCount = 0
+	s.correctedErrors = 0
+	s.uncorrectedErrors = 0
+	s.correctChars = 0
+	s.keystrokes = nil
+	s.mistypedWords = nil
+	s.typos = TypoSummary{}
+	s.saveErr = nil
+	s.newBest = nil
 	return s.Start()
 }
 
@@ -489,8 +582,9 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 
 	switch key.Type {
 	case tea.KeyBackspace:
-		if len(s.userInput) > 0 {
+		if len(s.userInput) > 0 && !s.NoBackspace {
 			s.backspaceCount++
+			s.recordKeystroke(false, true)
 			removedChar := s.userInput[len(s.userInput)-1]
 			s.userInput = s.userInput[:len(s.userInput)-1]
 			if s.position > 0 {
@@ -513,6 +607,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 					s.mistakes++
 					s.uncorrectedErrors++
 				}
+				s.recordKeystroke(char == expectedChar, false)
 			}
 			s.position++
 			if char == " " && s.showContext {
@@ -525,6 +620,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 	}
 
 	if (s.mode == "timed" || s.mode == "words" || (s.mode == "practice" && s.maxChunks == 0)) && s.position >= len(s.text) {
+		s.addTypos()
 		s.totalChars += len(s.userInput)
 		s.totalMistakes += s.mistakes
 
@@ -537,6 +633,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 
 	if s.mode == "practice" && s.maxChunks > 0 && s.position >= len(s.text) {
 		if s.isGroupMode {
+			s.addTypos()
 			s.totalChars += len(s.userInput)
 			s.totalMistakes += s.mistakes
 			s.totalChunks += s.currentPageChunks
@@ -563,7 +660,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 					BackspaceCount:    s.GetBackspaceCount(),
 					AvgWordLength:     s.GetAvgWordLength(),
 				}
-				SaveSessionRecord(s.config, record)
+				s.saveRecord(record)
 				s.mistakes = 0
 
 				return func() tea.Msg { return SessionCompleteMsg{} }
@@ -581,6 +678,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 			}
 			} else {
 				s.totalChunks++
+				s.addTypos()
 				s.totalChars += len(s.userInput)
 				s.totalMistakes += s.mistakes
 
@@ -600,7 +698,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 						Mistakes:    s.totalMistakes,
 						QuoteAuthor: s.author,
 					}
-					SaveSessionRecord(s.config, record)
+					s.saveRecord(record)
 
 					return func() tea.Msg { return SessionCompleteMsg{} }
 				} else {
@@ -615,6 +713,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 
 	if s.mode == "custom" && s.position >= len(s.text) {
 		s.chunkIndex++
+		s.addTypos()
 		s.totalChars += len(s.userInput)
 		s.totalMistakes += s.mistakes
 
@@ -640,7 +739,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 				BackspaceCount:    s.GetBackspaceCount(),
 				AvgWordLength:     s.GetAvgWordLength(),
 			}
-			SaveSessionRecord(s.config, record)
+			s.saveRecord(record)
 
 			return func() tea.Msg { return SessionCompleteMsg{} }
 		} else {
@@ -654,6 +753,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 
 	if s.mode == "quotes" && s.position >= len(s.text) {
 		s.chunkIndex++
+		s.addTypos()
 		s.totalChars += len(s.userInput)
 		s.totalMistakes += s.mistakes
 
@@ -679,7 +779,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 				BackspaceCount:    s.GetBackspaceCount(),
 				AvgWordLength:     s.GetAvgWordLength(),
 			}
-			SaveSessionRecord(s.config, record)
+			s.saveRecord(record)
 
 			return func() tea.Msg { return SessionCompleteMsg{} }
 		} else {
@@ -696,10 +796,11 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 		s.running = false
 		s.duration = time.Since(s.startTime)
 
+		s.addTypos()
 		s.totalChars += len(s.userInput)
 		s.totalMistakes += s.mistakes
 
-		if s.mode != "challenge" {
+		if s.mode != "challenge" && s.mode != "duel" {
 			record := &SessionRecord{
 				Mode:              s.mode,
 				Tier:              s.tier,
@@ -717,7 +818,7 @@ func (s *Session) HandleInput(key tea.KeyMsg) tea.Cmd {
 				BackspaceCount:    s.GetBackspaceCount(),
 				AvgWordLength:     s.GetAvgWordLength(),
 			}
-			SaveSessionRecord(s.config, record)
+			s.saveRecord(record)
 		}
 
 		return func() tea.Msg { return SessionCompleteMsg{} }
@@ -730,7 +831,7 @@ func (s *Session) completeSession() tea.Cmd {
 	s.completed = true
 	s.running = false
 	s.duration = time.Since(s.startTime)
-	if s.mode != "challenge" {
+	if s.mode != "challenge" && s.mode != "duel" {
 		record := &SessionRecord{
 			Mode:              s.mode,
 			Tier:              s.tier,
@@ -748,7 +849,7 @@ func (s *Session) completeSession() tea.Cmd {
 			BackspaceCount:    s.GetBackspaceCount(),
 			AvgWordLength:     s.GetAvgWordLength(),
 		}
-		SaveSessionRecord(s.config, record)
+		s.saveRecord(record)
 	}
 	s.mistakes = 0
 	return func() tea.Msg { return SessionCompleteMsg{} }
@@ -762,6 +863,8 @@ func (s *Session) UpdateTimer() tea.Cmd {
 			s.running = false
 			s.duration = s.timeLimit
 
+			s.addTypos()
+
 			mistakes := s.mistakes
 			if s.mode == "challenge" {
 				mistakes = s.totalMistakes
@@ -787,7 +890,7 @@ func (s *Session) UpdateTimer() tea.Cmd {
 				BackspaceCount:    s.GetBackspaceCount(),
 				AvgWordLength:     s.GetAvgWordLength(),
 			}
-			SaveSessionRecord(s.config, record)
+			s.saveRecord(record)
 			s.mistakes = 0
 
 			return func() tea.Msg { return SessionCompleteMsg{} }
@@ -805,7 +908,13 @@ func (s *Session) tickTimer() tea.Cmd {
 
 func (s *Session) View(width, height int) string {
 	status := s.renderStatus(width)
-	textArea := s.renderText(width, height)
+	lanes := s.renderLanes(width)
+	laneHeight := lipgloss.Height(lanes)
+	if lanes == "" || height-laneHeight < 6 {
+		lanes = ""
+		laneHeight = 0
+	}
+	textArea := s.renderText(width, height-laneHeight)
 	tipOrContext := s.renderTip(width)
 	if s.showContext {
 		tipOrContext = s.renderContext(width)
@@ -813,7 +922,9 @@ func (s *Session) View(width, height int) string {
 	hint := s.renderHint(width)
 
 	var content string
-	if height >= 6 {
+	if lanes != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, status, lanes, textArea, tipOrContext, hint)
+	} else if height >= 6 {
 
 		content = lipgloss.JoinVertical(lipgloss.Left, status, textArea, tipOrContext, hint)
 	} else if height >= 4 {
@@ -855,6 +966,9 @@ func (s *Session) renderStatus(width int) string {
 	if s.tier != "" {
 		mode += " (" + s.tier + ")"
 	}
+	if s.player != "" {
+		mode += " - " + s.player
+	}
 	timer := "00:00"
 	if s.running {
 		if s.mode == "challenge" {
@@ -876,20 +990,39 @@ func (s *Session) renderStatus(width int) string {
 
 	progress := s.calculateProgress()
 
+	// The goal indicator takes its room first; the rest of the status
+	// shortens to fit beside it.
+	room := width
+	goal := ""
+	if s.goals != nil && !s.HideErrors && width >= 60 {
+		var elapsed time.Duration
+		if s.running {
+			elapsed = time.Since(s.startTime)
+		}
+		goal = " | " + s.goals.StatusText(elapsed)
+		room -= len(goal)
+	}
+
+	full := fmt.Sprintf("Mode: %s | Timer: %s | WPM: %.1f | Accuracy: %.1f%% | Mistakes: %d | Progress: %.1f%%", mode, timer, wpm, accuracy, mistakes, progress)
 	var statusText string
-	if width >= 80 {
+	if s.HideErrors {
+		statusText = fmt.Sprintf("%s | %s | %.1f WPM", mode, timer, wpm)
+	} else if room >= 80 && (goal == "" || len(full) <= room) {
 
-		statusText = fmt.Sprintf("Mode: %s | Timer: %s | WPM: %.1f | Accuracy: %.1f%% | Mistakes: %d | Progress: %.1f%%", mode, timer, wpm, accuracy, mistakes, progress)
-	} else if width >= 60 {
+		statusText = full
+	} else if room >= 60 {
 
 		statusText = fmt.Sprintf("%s | %s | %.1f WPM | %.1f%% | %d mistakes", mode, timer, wpm, accuracy, mistakes)
-	} else if width >= 40 {
+	} else if room >= 40 {
 
 		statusText = fmt.Sprintf("%s | %s | %.1f WPM | %d errors", mode, timer, wpm, mistakes)
 	} else {
 
 		statusText = fmt.Sprintf("%s | %.1f WPM", mode, wpm)
 	}
+	if goal != "" && len(statusText)+len(goal) <= width {
+		statusText += goal
+	}
 
 	status := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(s.config.Theme.Colors.TextPrimary)).
@@ -901,6 +1034,65 @@ func (s *Session) renderStatus(width int) string {
 	return status
 }
 
+type Lane struct {
+	Name     string
+	Progress float64
+	WPM      float64
+	Finished bool
+	Self     bool
+}
+
+func (s *Session) renderLanes(width int) string {
+	if len(s.Lanes) == 0 {
+		return ""
+	}
+
+	nameWidth := 0
+	for _, lane := range s.Lanes {
+		nameWidth = max(nameWidth, len(lane.Name))
+	}
+	nameWidth = min(nameWidth, 16)
+
+	barWidth := min(40, width-nameWidth-24)
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	var lines []string
+	for _, lane := range s.Lanes {
+		progress := lane.Progress
+		if progress < 0 {
+			progress = 0
+		}
+		if progress > 100 {
+			progress = 100
+		}
+		filled := int(progress / 100 * float64(barWidth))
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+		name := lane.Name
+		if len(name) > nameWidth {
+			name = name[:nameWidth]
+		}
+		suffix := fmt.Sprintf("%3.0f%% %5.1f wpm", progress, lane.WPM)
+		if lane.Finished {
+			suffix = fmt.Sprintf("done %5.1f wpm", lane.WPM)
+		}
+
+		color := s.config.Theme.Colors.Pending
+		if lane.Self {
+			color = s.config.Theme.Colors.Accent
+		} else if lane.Finished {
+			color = s.config.Theme.Colors.Correct
+		}
+
+		line := fmt.Sprintf("%-*s %s %s", nameWidth, name, bar, suffix)
+		lines = append(lines, s.renderCenteredText(line, color, width))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
 func (s *Session) calculateDynamicWidth(content string, terminalWidth int) int {
 	actualWidth := lipgloss.Width(content)
 	contentWidth := actualWidth + 2
@@ -941,7 +1133,7 @@ func (s *Session) renderTextContent() string {
 	for i, char := range s.text {
 		style := lipgloss.NewStyle().Background(lipgloss.Color(s.config.Theme.Colors.Background))
 		if i < s.position {
-			if i < len(s.userInput) && rune(s.userInput[i]) == char {
+			if s.HideErrors || (i < len(s.userInput) && rune(s.userInput[i]) == char) {
 				style = style.Foreground(lipgloss.Color(s.config.Theme.Colors.Correct))
 			} else {
 				style = style.Foreground(lipgloss.Color(s.config.Theme.Colors.Incorrect))
@@ -961,6 +1153,9 @@ func (s *Session) renderTextContent() string {
 				}
 			}
 		}
+		if s.MaskPending && i >= s.position && char != ' ' && char != '\n' {
+			char = '_'
+		}
 		rendered.WriteString(style.Render(string(char)))
 	}
 
@@ -1145,6 +1340,11 @@ func (s *Session) SetTier(tier string) {
 	s.tier = tier
 }
 
+// SetPlayer names who is typing when several people share one terminal.
+func (s *Session) SetPlayer(player string) {
+	s.player = player
+}
+
 func (s *Session) ResetForNewText() {
 	s.position = 0
 	s.userInput = ""
@@ -1206,3 +1406,27 @@ func (s *Session) GetCorrectChars() int {
 func (s *Session) GetAvgWordLength() float64 {
 	return s.avgWordLength
 }
+
+func (s *Session) saveRecord(record *SessionRecord) {
+	record.Language = s.language
+	record.TextSource = s.source
+	record.Player = s.player
+	record.DurationTargetMs = s.timeLimit.Milliseconds()
+	typos := s.typos
+	record.Typos = &typos
+	s.newBest, s.saveErr = SaveSessionRecordWithBest(s.config, record)
+	if s.goalsLoaded {
+		s.loadGoals()
+	}
+}
+
+// NewBest is the personal best the last finished session set, if any.
+func (s *Session) NewBest() *NewBest {
+	return s.newBest
+}
+
+// SaveError is the error from saving the last finished session to history,
+// if any.
+func (s *Session) SaveError() error {
+	return s.saveErr
+}
@@ -0,0 +1,430 @@
+package session
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+const (
+	BackendJSONL = "jsonl"
+	BackendBolt  = "bolt"
+)
+
+var Backends = []string{BackendJSONL, BackendBolt}
+
+// Query selects history records. Zero values match everything; Since and
+// Until are inclusive.
+type Query struct {
+	Since    time.Time
+	Until    time.Time
+	Mode     string
+	Language string
+	MinWPM   float64
+	Limit    int
+}
+
+func (q Query) Matches(r *SessionRecord) bool {
+	if !q.Since.IsZero() && r.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && r.Timestamp.After(q.Until) {
+		return false
+	}
+	if q.Mode != "" && r.Mode != q.Mode {
+		return false
+	}
+	if q.Language != "" && r.Language != q.Language {
+		return false
+	}
+	if q.MinWPM > 0 && r.WPM < q.MinWPM {
+		return false
+	}
+	return true
+}
+
+// Store is where typing history lives. Every method opens and releases the
+// underlying file so several gti instances can share one history.
+type Store interface {
+	// Append adds a record as it is, without touching its timestamp.
+	Append(record *SessionRecord) error
+	// Load returns every record, newest first, upgraded to the current
+	// schema.
+	Load() ([]*SessionRecord, *LoadReport, error)
+	// Query returns matching records, newest first.
+	Query(q Query) ([]*SessionRecord, error)
+	// Replace swaps the whole history for records in one step.
+	Replace(records []*SessionRecord) error
+	// Rewrite loads every record, newest first, and replaces the history
+	// with what update returns, holding the store's lock throughout so a
+	// session saved by another gti instance in between is not lost.
+	// Nothing is written when update reports no change. Histories with
+	// unreadable records are refused, since rewriting would drop them.
+	Rewrite(update func(records []*SessionRecord) ([]*SessionRecord, bool)) error
+	Path() string
+}
+
+// NewStore returns the store for a backend, reading paths from history.
+func NewStore(backend string, history config.HistoryConfig) (Store, error) {
+	switch backend {
+	case "", BackendJSONL:
+		return &JSONLStore{path: config.ExpandPath(history.File)}, nil
+	case BackendBolt:
+		return &BoltStore{path: config.ExpandPath(history.Database)}, nil
+	default:
+		return nil, fmt.Errorf("unknown history backend '%s'. Valid options: jsonl, bolt", backend)
+	}
+}
+
+// OpenStore returns the store for the configured backend.
+func OpenStore(history config.HistoryConfig) (Store, error) {
+	return NewStore(history.Backend, history)
+}
+
+// JSONLStore keeps one JSON record per line, appended in the order sessions
+// finish. Queries read the whole file.
+type JSONLStore struct {
+	path string
+}
+
+func (s *JSONLStore) Path() string {
+	return s.path
+}
+
+// Append writes the record as a single line and syncs it to disk while
+// holding the history lock, so concurrent gti instances never interleave.
+// A partial line left by an earlier crash is repaired first.
+func (s *JSONLStore) Append(record *SessionRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	lock, err := lockHistory(s.path, true)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := repairTrailingLine(file, s.path+".rejected"); err != nil {
+		return fmt.Errorf("failed to repair history: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// repairTrailingLine fixes a last line that has no newline. A complete
+// record just gets its newline; anything else was cut off mid-write and is
+// moved to the rejected file.
+func repairTrailingLine(file *os.File, rejectedPath string) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	if size == 0 {
+		return nil
+	}
+
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, size-1); err != nil {
+		return err
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+
+	start := size
+	block := make([]byte, 4096)
+	for start > 0 {
+		n := int64(len(block))
+		if start < n {
+			n = start
+		}
+		if _, err := file.ReadAt(block[:n], start-n); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(block[:n], '\n'); i >= 0 {
+			start = start - n + int64(i) + 1
+			break
+		}
+		start -= n
+	}
+
+	fragment := make([]byte, size-start)
+	if _, err := file.ReadAt(fragment, start); err != nil {
+		return err
+	}
+	if json.Valid(fragment) {
+		_, err := file.WriteAt([]byte{'\n'}, size)
+		return err
+	}
+
+	rejected, err := os.OpenFile(rejectedPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = rejected.Write(append(fragment, '\n'))
+	if cerr := rejected.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := file.Truncate(start); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func (s *JSONLStore) Load() ([]*SessionRecord, *LoadReport, error) {
+	return LoadSessionRecordsWithReport(s.path)
+}
+
+func (s *JSONLStore) Query(q Query) ([]*SessionRecord, error) {
+	records, _, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*SessionRecord
+	for _, r := range records {
+		if !q.Matches(r) {
+			continue
+		}
+		matched = append(matched, r)
+		if q.Limit > 0 && len(matched) == q.Limit {
+			break
+		}
+	}
+	return matched, nil
+}
+
+// Replace rewrites the file oldest first, the order appends produce.
+func (s *JSONLStore) Replace(records []*SessionRecord) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	lock, err := lockHistory(s.path, true)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	return WriteRecordsAtomic(s.path, oldestFirst(records))
+}
+
+func (s *JSONLStore) Rewrite(update func(records []*SessionRecord) ([]*SessionRecord, bool)) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	lock, err := lockHistory(s.path, true)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	records, report, err := readHistoryFile(s.path)
+	if err != nil {
+		return err
+	}
+	if err := checkRewritable(report); err != nil {
+		return err
+	}
+	updated, changed := update(records)
+	if !changed {
+		return nil
+	}
+	return WriteRecordsAtomic(s.path, oldestFirst(updated))
+}
+
+func oldestFirst(records []*SessionRecord) []*SessionRecord {
+	sorted := make([]*SessionRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+	return sorted
+}
+
+// ConvertHistory copies every record from one store into another, skipping
+// sessions the destination already has. It returns how many were added.
+func ConvertHistory(from, to Store) (int, error) {
+	records, _, err := from.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	err = to.Rewrite(func(existing []*SessionRecord) ([]*SessionRecord, bool) {
+		seen := make(map[string]bool, len(existing))
+		for _, r := range existing {
+			seen[identity(r)] = true
+		}
+
+		merged := existing
+		for _, r := range records {
+			key := identity(r)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, r)
+			added++
+		}
+		return merged, added > 0
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// ID is a short, stable identifier for a record, for display and for
+// picking a session out by prefix. It is a truncated hash, so code that must
+// tell records apart compares identity instead.
+func (r *SessionRecord) ID() string {
+	sum := sha1.Sum([]byte(identity(r)))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// identity is the full key a record is known by: the moment the session
+// finished. Imported sessions may only know the day they were played, so
+// their import key is mixed in to keep them apart.
+func identity(r *SessionRecord) string {
+	key := r.Timestamp.UTC().Format(time.RFC3339Nano)
+	if r.Source != "" {
+		key += "|" + importKey(r)
+	}
+	return key
+}
+
+// MinIDPrefix is the shortest id prefix FindRecord accepts, so a stray
+// character cannot pick out a session by accident.
+const MinIDPrefix = 4
+
+// FindRecord returns the record whose ID starts with prefix. The prefix
+// must be at least MinIDPrefix characters and match exactly one record.
+func FindRecord(records []*SessionRecord, prefix string) (*SessionRecord, error) {
+	prefix = strings.ToLower(prefix)
+	if len(prefix) < MinIDPrefix {
+		return nil, fmt.Errorf("session id '%s' is too short: use at least %d characters", prefix, MinIDPrefix)
+	}
+	var found *SessionRecord
+	for _, r := range records {
+		if !strings.HasPrefix(r.ID(), prefix) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("session id '%s' is ambiguous", prefix)
+		}
+		found = r
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no session with id '%s'", prefix)
+	}
+	return found, nil
+}
+
+// DeleteRecords removes the given records from the store in one atomic
+// rewrite and returns how many were removed.
+func DeleteRecords(store Store, remove []*SessionRecord) (int, error) {
+	drop := make(map[string]bool, len(remove))
+	for _, r := range remove {
+		drop[identity(r)] = true
+	}
+
+	removed := 0
+	err := store.Rewrite(func(records []*SessionRecord) ([]*SessionRecord, bool) {
+		kept := make([]*SessionRecord, 0, len(records))
+		for _, r := range records {
+			if !drop[identity(r)] {
+				kept = append(kept, r)
+			}
+		}
+		removed = len(records) - len(kept)
+		return kept, removed > 0
+	})
+	if err != nil {
+		return 0, err
+	}
+	if removed > 0 {
+		invalidatePersonalBests()
+	}
+	return removed, nil
+}
+
+// ImportRecords adds records from another trainer, skipping any that were
+// imported before. It returns how many were added and skipped.
+func ImportRecords(store Store, imported []*SessionRecord) (int, int, error) {
+	added, skipped := 0, 0
+	err := store.Rewrite(func(records []*SessionRecord) ([]*SessionRecord, bool) {
+		seen := make(map[string]bool, len(records))
+		for _, r := range records {
+			seen[importKey(r)] = true
+		}
+
+		for _, r := range imported {
+			key := importKey(r)
+			if seen[key] {
+				skipped++
+				continue
+			}
+			seen[key] = true
+			r.SchemaVersion = CurrentSchemaVersion
+			records = append(records, r)
+			added++
+		}
+		return records, added > 0
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if added > 0 {
+		invalidatePersonalBests()
+	}
+	return added, skipped, nil
+}
+
+// importKey identifies an imported session across repeated imports. Without
+// an id from the source, speed and duration tell apart sessions that share
+// a timestamp.
+func importKey(r *SessionRecord) string {
+	if r.Source != "" && r.SourceID != "" {
+		return r.Source + ":" + r.SourceID
+	}
+	return fmt.Sprintf("%s@%s/%.2f/%d", r.Source, r.Timestamp.UTC().Format(time.RFC3339Nano), r.WPM, r.DurationMs)
+}
+
+// checkRewritable stops a rewrite that would lose lines that could not be
+// read.
+func checkRewritable(report *LoadReport) error {
+	if report.HasProblems() {
+		return fmt.Errorf("history has %d unreadable lines; run 'gti history migrate' first", len(report.Unreadable))
+	}
+	return nil
+}
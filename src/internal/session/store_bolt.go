@@ -0,0 +1,278 @@
+package session
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	sessionsBucket   = []byte("sessions")
+	byModeBucket     = []byte("by_mode")
+	byLanguageBucket = []byte("by_language")
+)
+
+// How long to wait for another gti instance to release the database.
+const boltOpenTimeout = 2 * time.Second
+
+// BoltStore keeps history in an embedded database. Sessions are keyed by
+// timestamp, so time ranges are a cursor walk, and mode and language have
+// their own index buckets pointing back at those keys.
+type BoltStore struct {
+	path string
+}
+
+func (s *BoltStore) Path() string {
+	return s.path
+}
+
+func (s *BoltStore) open(readOnly bool) (*bolt.DB, error) {
+	if readOnly {
+		if _, err := os.Stat(s.path); err != nil {
+			return nil, err
+		}
+	} else if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return nil, err
+	}
+	return bolt.Open(s.path, 0644, &bolt.Options{Timeout: boltOpenTimeout, ReadOnly: readOnly})
+}
+
+// recordKey sorts by time; the sequence keeps sessions that finish in the
+// same nanosecond apart.
+func recordKey(t time.Time, seq uint64) []byte {
+	key := make([]byte, 16)
+	copy(key, timeKey(t))
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	nanos := t.UnixNano()
+	if t.Before(time.Unix(0, 0)) {
+		nanos = 0
+	}
+	binary.BigEndian.PutUint64(key, uint64(nanos))
+	return key
+}
+
+func keyTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
+
+func putRecord(tx *bolt.Tx, record *SessionRecord) error {
+	sessions, err := tx.CreateBucketIfNotExists(sessionsBucket)
+	if err != nil {
+		return err
+	}
+	seq, err := sessions.NextSequence()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	key := recordKey(record.Timestamp, seq)
+	if err := sessions.Put(key, data); err != nil {
+		return err
+	}
+	if err := putIndex(tx, byModeBucket, record.Mode, key); err != nil {
+		return err
+	}
+	return putIndex(tx, byLanguageBucket, record.Language, key)
+}
+
+func putIndex(tx *bolt.Tx, name []byte, value string, key []byte) error {
+	if value == "" {
+		return nil
+	}
+	index, err := tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return err
+	}
+	bucket, err := index.CreateBucketIfNotExists([]byte(value))
+	if err != nil {
+		return err
+	}
+	return bucket.Put(key, nil)
+}
+
+func (s *BoltStore) Append(record *SessionRecord) error {
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return putRecord(tx, record)
+	})
+}
+
+func (s *BoltStore) Load() ([]*SessionRecord, *LoadReport, error) {
+	report := &LoadReport{Path: s.path}
+	records, err := s.query(Query{}, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	report.Loaded = len(records)
+	return records, report, nil
+}
+
+func (s *BoltStore) Query(q Query) ([]*SessionRecord, error) {
+	return s.query(q, &LoadReport{Path: s.path})
+}
+
+// query walks the narrowest index for q from newest to oldest, stopping at
+// Since or once Limit records have matched.
+func (s *BoltStore) query(q Query, report *LoadReport) ([]*SessionRecord, error) {
+	db, err := s.open(true)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*SessionRecord{}, nil
+		}
+		return nil, err
+	}
+	defer db.Close()
+
+	var records []*SessionRecord
+	err = db.View(func(tx *bolt.Tx) error {
+		records = queryTx(tx, q, report)
+		return nil
+	})
+	return records, err
+}
+
+func queryTx(tx *bolt.Tx, q Query, report *LoadReport) []*SessionRecord {
+	records := []*SessionRecord{}
+	sessions := tx.Bucket(sessionsBucket)
+	if sessions == nil {
+		return records
+	}
+
+	walk := sessions
+	switch {
+	case q.Mode != "":
+		walk = indexBucket(tx, byModeBucket, q.Mode)
+	case q.Language != "":
+		walk = indexBucket(tx, byLanguageBucket, q.Language)
+	}
+	if walk == nil {
+		return records
+	}
+
+	c := walk.Cursor()
+	var k []byte
+	if q.Until.IsZero() {
+		k, _ = c.Last()
+	} else if k, _ = c.Seek(timeKey(q.Until.Add(time.Nanosecond))); k == nil {
+		k, _ = c.Last()
+	} else {
+		k, _ = c.Prev()
+	}
+
+	for ; k != nil; k, _ = c.Prev() {
+		if !q.Since.IsZero() && keyTime(k).Before(q.Since) {
+			break
+		}
+
+		data := sessions.Get(k)
+		var record SessionRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			report.Unreadable = append(report.Unreadable, UnreadableLine{
+				Text:  string(data),
+				Error: err.Error(),
+			})
+			continue
+		}
+		if record.SchemaVersion > CurrentSchemaVersion {
+			report.Newer++
+		}
+		if MigrateRecord(&record) {
+			report.Migrated++
+		}
+		if !q.Matches(&record) {
+			continue
+		}
+
+		records = append(records, &record)
+		if q.Limit > 0 && len(records) == q.Limit {
+			break
+		}
+	}
+	return records
+}
+
+func indexBucket(tx *bolt.Tx, name []byte, value string) *bolt.Bucket {
+	index := tx.Bucket(name)
+	if index == nil {
+		return nil
+	}
+	return index.Bucket([]byte(value))
+}
+
+// Replace rebuilds every bucket inside a single transaction, so readers see
+// either the old history or the new one.
+func (s *BoltStore) Replace(records []*SessionRecord) error {
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return replaceTx(tx, records)
+	})
+}
+
+// Rewrite reads and rebuilds the history in one write transaction, which
+// holds the database open exclusively until it commits. When update changes
+// nothing the transaction is rolled back, since committing even an empty one
+// writes a new meta page.
+func (s *BoltStore) Rewrite(update func(records []*SessionRecord) ([]*SessionRecord, bool)) error {
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	report := &LoadReport{Path: s.path}
+	records := queryTx(tx, Query{}, report)
+	if err := checkRewritable(report); err != nil {
+		return err
+	}
+	updated, changed := update(records)
+	if !changed {
+		return nil
+	}
+	if err := replaceTx(tx, updated); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceTx(tx *bolt.Tx, records []*SessionRecord) error {
+	for _, name := range [][]byte{sessionsBucket, byModeBucket, byLanguageBucket} {
+		if err := tx.DeleteBucket(name); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+	}
+	for _, record := range records {
+		if err := putRecord(tx, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
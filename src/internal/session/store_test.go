@@ -0,0 +1,395 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gti/src/internal/config"
+)
+
+func testRecord(at time.Time, mode string, wpm float64) *SessionRecord {
+	return &SessionRecord{
+		SchemaVersion: CurrentSchemaVersion,
+		Timestamp:     at,
+		Mode:          mode,
+		Language:      "english",
+		TextSource:    "words",
+		DurationMs:    30000,
+		WPM:           wpm,
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestJSONLAppendRepairsTrailingLine(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	complete := `{"schema_version":3,"timestamp":"2026-01-01T09:00:00Z","mode":"timed","wpm":40}`
+
+	tests := []struct {
+		name     string
+		existing string
+		lines    int
+		rejected string
+	}{
+		{
+			name:     "truncated line is moved aside",
+			existing: complete + "\n" + `{"timestamp":"2026-01-01T09:30:00Z","wp`,
+			lines:    2,
+			rejected: `{"timestamp":"2026-01-01T09:30:00Z","wp` + "\n",
+		},
+		{
+			name:     "complete line gets its newline",
+			existing: complete,
+			lines:    2,
+		},
+		{
+			name:     "only a fragment",
+			existing: `{"time`,
+			lines:    1,
+			rejected: `{"time` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history.jsonl")
+			if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			store := &JSONLStore{path: path}
+			if err := store.Append(testRecord(base, "timed", 50)); err != nil {
+				t.Fatal(err)
+			}
+
+			records, report, err := store.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.HasProblems() {
+				t.Errorf("unreadable lines after repair: %+v", report.Unreadable)
+			}
+			if len(records) != tt.lines {
+				t.Errorf("loaded %d records, want %d", len(records), tt.lines)
+			}
+			if !strings.HasSuffix(readFile(t, path), "\n") {
+				t.Error("history does not end with a newline")
+			}
+
+			rejected, err := os.ReadFile(path + ".rejected")
+			if tt.rejected == "" {
+				if err == nil {
+					t.Errorf("rejected file written: %q", rejected)
+				}
+				return
+			}
+			if string(rejected) != tt.rejected {
+				t.Errorf("rejected = %q, want %q", rejected, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, backend := range Backends {
+		t.Run(backend, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := NewStore(backend, testHistoryConfig(dir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 3; i++ {
+				if err := store.Append(testRecord(base.Add(time.Duration(i)*time.Minute), "timed", float64(40+i))); err != nil {
+					t.Fatal(err)
+				}
+			}
+			before, err := os.Stat(store.Path())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var seen int
+			err = store.Rewrite(func(records []*SessionRecord) ([]*SessionRecord, bool) {
+				seen = len(records)
+				return nil, false
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seen != 3 {
+				t.Errorf("update saw %d records, want 3", seen)
+			}
+			after, err := os.Stat(store.Path())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !after.ModTime().Equal(before.ModTime()) || after.Size() != before.Size() {
+				t.Error("rewrite without a change touched the history")
+			}
+
+			err = store.Rewrite(func(records []*SessionRecord) ([]*SessionRecord, bool) {
+				return records[1:], true
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			records, _, err := store.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 2 || records[0].WPM != 41 || records[1].WPM != 40 {
+				t.Errorf("after dropping the newest, records = %v, want wpm 41 and 40", wpms(records))
+			}
+		})
+	}
+}
+
+func TestRewriteRefusesUnreadableLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	content := `{"schema_version":3,"timestamp":"2026-01-01T09:00:00Z","mode":"timed","wpm":40}` + "\nnot json\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &JSONLStore{path: path}
+	called := false
+	err := store.Rewrite(func(records []*SessionRecord) ([]*SessionRecord, bool) {
+		called = true
+		return records, true
+	})
+	if err == nil {
+		t.Fatal("rewrite of a history with unreadable lines succeeded")
+	}
+	if called {
+		t.Error("update ran on a history with unreadable lines")
+	}
+	if got := readFile(t, path); got != content {
+		t.Errorf("history changed to %q", got)
+	}
+}
+
+func TestConvertHistoryRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	history := testHistoryConfig(dir)
+	jsonl, _ := NewStore(BackendJSONL, history)
+	bolt, _ := NewStore(BackendBolt, history)
+
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	originals := []*SessionRecord{
+		testRecord(base, "timed", 40),
+		testRecord(base.Add(time.Minute), "quote", 50),
+		testRecord(base.Add(2*time.Minute), "timed", 60),
+		testRecord(collidingA, "words", 45),
+		testRecord(collidingB, "words", 55),
+		// Imported sessions that only know their day share a timestamp.
+		{SchemaVersion: CurrentSchemaVersion, Timestamp: day, Mode: "practice", WPM: 30, DurationMs: 30000, Source: "csv"},
+		{SchemaVersion: CurrentSchemaVersion, Timestamp: day, Mode: "practice", WPM: 35, DurationMs: 30000, Source: "csv"},
+	}
+	for _, r := range originals {
+		if err := jsonl.Append(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	added, err := ConvertHistory(jsonl, bolt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != len(originals) {
+		t.Errorf("converted %d records to bolt, want %d", added, len(originals))
+	}
+
+	timed, err := bolt.Query(Query{Mode: "timed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := wpms(timed); len(got) != 2 || got[0] != 60 || got[1] != 40 {
+		t.Errorf("bolt mode index returned wpm %v, want [60 40]", got)
+	}
+
+	if err := os.Remove(jsonl.Path()); err != nil {
+		t.Fatal(err)
+	}
+	added, err = ConvertHistory(bolt, jsonl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != len(originals) {
+		t.Errorf("converted %d records back to jsonl, want %d", added, len(originals))
+	}
+
+	back, _, err := jsonl.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := wpms(back); len(got) != len(originals) || got[0]+got[1] != 65 || !equalFloats(got[2:], []float64{60, 50, 55, 45, 40}) {
+		t.Errorf("round trip gave wpm %v, want the imports then 60 50 55 45 40", got)
+	}
+
+	added, err = ConvertHistory(jsonl, bolt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 0 {
+		t.Errorf("converting again added %d records, want 0", added)
+	}
+}
+
+func testHistoryConfig(dir string) config.HistoryConfig {
+	return config.HistoryConfig{
+		File:     filepath.Join(dir, "history.jsonl"),
+		Database: filepath.Join(dir, "history.db"),
+	}
+}
+
+func wpms(records []*SessionRecord) []float64 {
+	var values []float64
+	for _, r := range records {
+		values = append(values, r.WPM)
+	}
+	return values
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// useTempDataDir points the data directory at a scratch one, so stores that
+// reset personal bests never touch the real index.
+func useTempDataDir(t *testing.T) {
+	t.Helper()
+	dataDir := config.DataDir
+	config.DataDir = t.TempDir()
+	t.Cleanup(func() { config.DataDir = dataDir })
+}
+
+// These two moments hash to the same short id, 6f49e134.
+var (
+	collidingA = time.Date(2026, 1, 1, 10, 0, 45, 69000000, time.UTC)
+	collidingB = time.Date(2026, 1, 1, 10, 0, 51, 267000000, time.UTC)
+)
+
+func TestFindRecord(t *testing.T) {
+	first := testRecord(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), "timed", 40)
+	second := testRecord(time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC), "timed", 50)
+	a := testRecord(collidingA, "timed", 60)
+	b := testRecord(collidingB, "timed", 70)
+	records := []*SessionRecord{first, second, a, b}
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   *SessionRecord
+	}{
+		{name: "full id", prefix: first.ID(), want: first},
+		{name: "shortest prefix", prefix: second.ID()[:MinIDPrefix], want: second},
+		{name: "upper case", prefix: strings.ToUpper(second.ID()), want: second},
+		{name: "too short", prefix: first.ID()[:MinIDPrefix-1]},
+		{name: "ambiguous", prefix: a.ID()},
+		{name: "unknown", prefix: "zzzz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindRecord(records, tt.prefix)
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("FindRecord(%q) = %+v, want an error", tt.prefix, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindRecord(%q): %v", tt.prefix, err)
+			}
+			if got != tt.want {
+				t.Errorf("FindRecord(%q) = %+v, want %+v", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRecords(t *testing.T) {
+	useTempDataDir(t)
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	imported := func(wpm float64) *SessionRecord {
+		return &SessionRecord{SchemaVersion: CurrentSchemaVersion, Timestamp: day, Mode: "practice", WPM: wpm, DurationMs: 30000, Source: "csv"}
+	}
+
+	tests := []struct {
+		name    string
+		records []*SessionRecord
+		remove  []int
+		kept    []float64
+	}{
+		{
+			name:    "one of several",
+			records: []*SessionRecord{testRecord(collidingA.Add(-time.Hour), "timed", 40), testRecord(collidingA, "timed", 50)},
+			remove:  []int{0},
+			kept:    []float64{50},
+		},
+		{
+			name:    "same short id",
+			records: []*SessionRecord{testRecord(collidingA, "timed", 60), testRecord(collidingB, "timed", 70)},
+			remove:  []int{0},
+			kept:    []float64{70},
+		},
+		{
+			name:    "imports on the same day",
+			records: []*SessionRecord{imported(30), imported(35)},
+			remove:  []int{1},
+			kept:    []float64{30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &JSONLStore{path: filepath.Join(t.TempDir(), "history.jsonl")}
+			for _, r := range tt.records {
+				if err := store.Append(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var remove []*SessionRecord
+			for _, i := range tt.remove {
+				remove = append(remove, tt.records[i])
+			}
+			removed, err := DeleteRecords(store, remove)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if removed != len(tt.remove) {
+				t.Errorf("removed %d records, want %d", removed, len(tt.remove))
+			}
+
+			records, _, err := store.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := wpms(records); !equalFloats(got, tt.kept) {
+				t.Errorf("kept wpm %v, want %v", got, tt.kept)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package session
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Keystroke is one key press in a session, timed from its start.
+type Keystroke struct {
+	At        time.Duration
+	Correct   bool
+	Backspace bool
+}
+
+// SecondStat is the speed and errors in one second of a session. RawWPM
+// counts every key typed during that second; NetWPM is the running speed
+// of correct keys from the start up to the end of the second.
+type SecondStat struct {
+	Second int
+	RawWPM float64
+	NetWPM float64
+	Errors int
+}
+
+// MistypedWord is a word from the text that was typed wrong at least once.
+type MistypedWord struct {
+	Word  string
+	Count int
+}
+
+// BuildTimeline splits keystrokes into one entry per second of duration.
+// The last second is scaled by how much of it the session actually ran.
+func BuildTimeline(keys []Keystroke, duration time.Duration) []SecondStat {
+	if duration <= 0 {
+		return nil
+	}
+	seconds := int(math.Ceil(duration.Seconds()))
+	timeline := make([]SecondStat, seconds)
+	typed := make([]int, seconds)
+	correct := make([]int, seconds)
+
+	for _, k := range keys {
+		i := int(k.At / time.Second)
+		if i >= seconds {
+			i = seconds - 1
+		}
+		if i < 0 || k.Backspace {
+			continue
+		}
+		typed[i]++
+		if k.Correct {
+			correct[i]++
+		} else {
+			timeline[i].Errors++
+		}
+	}
+
+	totalCorrect := 0
+	for i := range timeline {
+		end := time.Duration(i+1) * time.Second
+		if end > duration {
+			end = duration
+		}
+		length := end - time.Duration(i)*time.Second
+		totalCorrect += correct[i]
+
+		timeline[i].Second = i + 1
+		timeline[i].RawWPM = CalculateWPM(typed[i], length)
+		timeline[i].NetWPM = CalculateWPM(totalCorrect, end)
+	}
+	return timeline
+}
+
+// Consistency scores how steady the raw speed was from second to second:
+// 100 minus the coefficient of variation, so an even pace scores near 100.
+// Sessions shorter than two seconds score 0.
+func Consistency(timeline []SecondStat) float64 {
+	if len(timeline) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, t := range timeline {
+		sum += t.RawWPM
+	}
+	mean := sum / float64(len(timeline))
+	if mean <= 0 {
+		return 0
+	}
+
+	var variance float64
+	for _, t := range timeline {
+		variance += (t.RawWPM - mean) * (t.RawWPM - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(timeline)))
+	return math.Max(0, 100-stdDev/mean*100)
+}
+
+// wordAt returns the whitespace-separated word of text around pos.
+func wordAt(text string, pos int) string {
+	if pos < 0 || pos >= len(text) {
+		return ""
+	}
+	start := strings.LastIndexAny(text[:pos], " \n\t") + 1
+	end := strings.IndexAny(text[pos:], " \n\t")
+	if end < 0 {
+		end = len(text)
+	} else {
+		end += pos
+	}
+	return text[start:end]
+}
+
+// recordKeystroke adds a key press to the session's timeline and, for a
+// mistyped character, notes the word it belonged to.
+func (s *Session) recordKeystroke(correct, backspace bool) {
+	s.keystrokes = append(s.keystrokes, Keystroke{
+		At:        time.Since(s.startTime),
+		Correct:   correct,
+		Backspace: backspace,
+	})
+	if correct || backspace {
+		return
+	}
+
+	word := wordAt(s.text, s.position)
+	if word == "" {
+		return
+	}
+	for i := range s.mistypedWords {
+		if s.mistypedWords[i].Word == word {
+			s.mistypedWords[i].Count++
+			return
+		}
+	}
+	s.mistypedWords = append(s.mistypedWords, MistypedWord{Word: word, Count: 1})
+}
+
+// Keystrokes lists every key press of the session, in order.
+func (s *Session) Keystrokes() []Keystroke {
+	return s.keystrokes
+}
+
+// MistypedWords lists the words typed wrong, in the order first missed.
+func (s *Session) MistypedWords() []MistypedWord {
+	return s.mistypedWords
+}
@@ -0,0 +1,199 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxAlignCells caps the size of the alignment table. Longer texts are
+// compared character by character instead, which only finds substitutions.
+const maxAlignCells = 4_000_000
+
+// Confusion counts how often one expected character was typed as another.
+type Confusion struct {
+	Expected string `json:"expected"`
+	Typed    string `json:"typed"`
+	Count    int    `json:"count"`
+}
+
+// TypoSummary classifies typos by aligning typed text with the text that
+// was expected, so one skipped letter counts as a single omission rather
+// than a mistake at every position after it.
+type TypoSummary struct {
+	Substitutions  int `json:"substitutions"`
+	Insertions     int `json:"insertions"`
+	Omissions      int `json:"omissions"`
+	Transpositions int `json:"transpositions"`
+
+	// Confusions lists substituted characters, most frequent first.
+	Confusions []Confusion `json:"confusions,omitempty"`
+}
+
+func (t TypoSummary) Total() int {
+	return t.Substitutions + t.Insertions + t.Omissions + t.Transpositions
+}
+
+// Add merges other into t.
+func (t *TypoSummary) Add(other TypoSummary) {
+	t.Substitutions += other.Substitutions
+	t.Insertions += other.Insertions
+	t.Omissions += other.Omissions
+	t.Transpositions += other.Transpositions
+	for _, c := range other.Confusions {
+		t.addConfusion(c.Expected, c.Typed, c.Count)
+	}
+}
+
+func (t *TypoSummary) addConfusion(expected, typed string, count int) {
+	for i := range t.Confusions {
+		if t.Confusions[i].Expected == expected && t.Confusions[i].Typed == typed {
+			t.Confusions[i].Count += count
+			t.sortConfusions()
+			return
+		}
+	}
+	t.Confusions = append(t.Confusions, Confusion{Expected: expected, Typed: typed, Count: count})
+	t.sortConfusions()
+}
+
+func (t *TypoSummary) sortConfusions() {
+	sort.SliceStable(t.Confusions, func(i, j int) bool {
+		return t.Confusions[i].Count > t.Confusions[j].Count
+	})
+}
+
+// String shows a confusion as expected→typed, with visible whitespace.
+func (c Confusion) String() string {
+	return fmt.Sprintf("%s→%s", visibleChar(c.Expected), visibleChar(c.Typed))
+}
+
+// FormatConfusions lists up to limit confusions as "e→r ×3, i→o".
+func FormatConfusions(confusions []Confusion, limit int) string {
+	var parts []string
+	for i, c := range confusions {
+		if i == limit {
+			break
+		}
+		if c.Count > 1 {
+			parts = append(parts, fmt.Sprintf("%s ×%d", c, c.Count))
+		} else {
+			parts = append(parts, c.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func visibleChar(c string) string {
+	switch c {
+	case " ":
+		return "␣"
+	case "\n":
+		return "↵"
+	case "\t":
+		return "⇥"
+	}
+	return c
+}
+
+// ClassifyTypos aligns typed with expected using the optimal string
+// alignment distance, where swapping two neighbouring characters is one
+// edit. When partial is set, typing stopped part way through expected, so
+// the untyped rest of it is not counted as omitted.
+func ClassifyTypos(expectedText, typedText string, partial bool) TypoSummary {
+	var summary TypoSummary
+	expected, typed := []rune(expectedText), []rune(typedText)
+	m, n := len(expected), len(typed)
+	if (m+1)*(n+1) > maxAlignCells {
+		for i := 0; i < n && i < m; i++ {
+			if expected[i] != typed[i] {
+				summary.Substitutions++
+				summary.addConfusion(string(expected[i]), string(typed[i]), 1)
+			}
+		}
+		return summary
+	}
+
+	d := make([][]int, m+1)
+	for i := range d {
+		d[i] = make([]int, n+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= n; j++ {
+		d[0][j] = j
+	}
+	for i := 1; i <= m; i++ {
+		for j := 1; j <= n; j++ {
+			cost := 1
+			if expected[i-1] == typed[j-1] {
+				cost = 0
+			}
+			best := d[i-1][j-1] + cost
+			if d[i-1][j]+1 < best {
+				best = d[i-1][j] + 1
+			}
+			if d[i][j-1]+1 < best {
+				best = d[i][j-1] + 1
+			}
+			if isTransposition(expected, typed, i, j) && d[i-2][j-2]+1 < best {
+				best = d[i-2][j-2] + 1
+			}
+			d[i][j] = best
+		}
+	}
+
+	// A partial attempt ends wherever typed lines up best with expected. On
+	// a tie the furthest point wins, so a wrong last key is a substitution
+	// rather than an extra character.
+	i, j := m, n
+	if partial {
+		for k := 0; k <= m; k++ {
+			if d[k][n] <= d[i][n] {
+				i = k
+			}
+		}
+	}
+
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && expected[i-1] == typed[j-1] && d[i][j] == d[i-1][j-1]:
+			i, j = i-1, j-1
+		case isTransposition(expected, typed, i, j) && d[i][j] == d[i-2][j-2]+1:
+			summary.Transpositions++
+			i, j = i-2, j-2
+		case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+1:
+			summary.Substitutions++
+			summary.addConfusion(string(expected[i-1]), string(typed[j-1]), 1)
+			i, j = i-1, j-1
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			summary.Omissions++
+			i--
+		default:
+			summary.Insertions++
+			j--
+		}
+	}
+	return summary
+}
+
+func isTransposition(expected, typed []rune, i, j int) bool {
+	return i > 1 && j > 1 &&
+		expected[i-1] == typed[j-2] && expected[i-2] == typed[j-1] &&
+		expected[i-1] != expected[i-2]
+}
+
+// addTypos classifies the chunk in progress. It is called whenever a chunk
+// is finished or the session ends part way through one.
+func (s *Session) addTypos() {
+	if len(s.userInput) == 0 {
+		return
+	}
+	partial := utf8.RuneCountInString(s.userInput) < utf8.RuneCountInString(s.text)
+	s.typos.Add(ClassifyTypos(s.text, s.userInput, partial))
+}
+
+// Typos is the typo breakdown of the session so far.
+func (s *Session) Typos() TypoSummary {
+	return s.typos
+}
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyTypos(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		typed    string
+		partial  bool
+		want     TypoSummary
+	}{
+		{
+			name:     "clean",
+			expected: "hello world",
+			typed:    "hello world",
+		},
+		{
+			name:     "substitution",
+			expected: "hello world",
+			typed:    "hellp world",
+			want: TypoSummary{
+				Substitutions: 1,
+				Confusions:    []Confusion{{Expected: "o", Typed: "p", Count: 1}},
+			},
+		},
+		{
+			name:     "omission",
+			expected: "hello world",
+			typed:    "helo world",
+			want:     TypoSummary{Omissions: 1},
+		},
+		{
+			name:     "insertion",
+			expected: "hello world",
+			typed:    "helllo world",
+			want:     TypoSummary{Insertions: 1},
+		},
+		{
+			name:     "transposition",
+			expected: "hello world",
+			typed:    "hello wrold",
+			want:     TypoSummary{Transpositions: 1},
+		},
+		{
+			name:     "partial input is not omitted",
+			expected: "hello world",
+			typed:    "hello w",
+			partial:  true,
+		},
+		{
+			name:     "partial input ending on a wrong key",
+			expected: "hello world",
+			typed:    "hellp",
+			partial:  true,
+			want: TypoSummary{
+				Substitutions: 1,
+				Confusions:    []Confusion{{Expected: "o", Typed: "p", Count: 1}},
+			},
+		},
+		{
+			name:     "whole text counts the untyped rest",
+			expected: "hello world",
+			typed:    "hello",
+			want:     TypoSummary{Omissions: 6},
+		},
+		{
+			name:     "multibyte substitution",
+			expected: "привет",
+			typed:    "прибет",
+			want: TypoSummary{
+				Substitutions: 1,
+				Confusions:    []Confusion{{Expected: "в", Typed: "б", Count: 1}},
+			},
+		},
+		{
+			name:     "multibyte transposition",
+			expected: "привет",
+			typed:    "пирвет",
+			want:     TypoSummary{Transpositions: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyTypos(tt.expected, tt.typed, tt.partial)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClassifyTypos(%q, %q, %v) = %+v, want %+v", tt.expected, tt.typed, tt.partial, got, tt.want)
+			}
+		})
+	}
+}
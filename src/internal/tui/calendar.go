@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gti/src/internal/analytics"
+	"gti/src/internal/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var calendarRowLabels = [7]string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+
+// calendarShades runs from the border colour for empty days to the correct
+// colour for the busiest. Themes that do not use #rrggbb colours get the two
+// end colours only.
+func calendarShades(colors config.ThemeColorsConfig) [analytics.CalendarLevels]lipgloss.Style {
+	var shades [analytics.CalendarLevels]lipgloss.Style
+	last := analytics.CalendarLevels - 1
+	for level := range shades {
+		color := colors.Correct
+		if level == 0 {
+			color = colors.Border
+		} else if blended, ok := blendHex(colors.Border, colors.Correct, float64(level)/float64(last)); ok {
+			color = blended
+		}
+		shades[level] = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+	return shades
+}
+
+func blendHex(from, to string, t float64) (string, bool) {
+	a, ok := parseHex(from)
+	if !ok {
+		return "", false
+	}
+	b, ok := parseHex(to)
+	if !ok {
+		return "", false
+	}
+	var mixed [3]int
+	for i := range mixed {
+		mixed[i] = int(float64(a[i]) + (float64(b[i])-float64(a[i]))*t + 0.5)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", mixed[0], mixed[1], mixed[2]), true
+}
+
+func parseHex(color string) ([3]int, bool) {
+	var rgb [3]int
+	if len(color) != 7 || color[0] != '#' {
+		return rgb, false
+	}
+	for i := range rgb {
+		v, err := strconv.ParseUint(color[1+2*i:3+2*i], 16, 8)
+		if err != nil {
+			return rgb, false
+		}
+		rgb[i] = int(v)
+	}
+	return rgb, true
+}
+
+func (m StatisticsModel) renderCalendar() string {
+	s := m.styles
+	metric := analytics.CalendarMetrics[m.calendarMetric]
+	cal := analytics.BuildCalendar(m.filter.Apply(m.records), metric, time.Now())
+	shades := calendarShades(m.config.Theme.Colors)
+
+	var b strings.Builder
+	b.WriteString(s.section.Render(fmt.Sprintf("PRACTICE CALENDAR (LAST %d WEEKS)", analytics.CalendarWeeks)))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 79))
+	b.WriteString("\n")
+	b.WriteString(s.subtle.Render(fmt.Sprintf("Shaded by %s per day   [c] change", metric.Label())))
+	b.WriteString("\n\n")
+
+	// Label the week each month starts in, skipping labels that would run
+	// into the previous one.
+	months := []rune(strings.Repeat(" ", len(cal.Weeks)+3))
+	next := 0
+	for w, week := range cal.Weeks {
+		for _, day := range week {
+			if (day.Date.Day() == 1 || w == 0) && w >= next {
+				copy(months[w:], []rune(day.Date.Format("Jan")))
+				next = w + 4
+				break
+			}
+		}
+	}
+	b.WriteString("    ")
+	b.WriteString(s.subtle.Render(strings.TrimRight(string(months), " ")))
+	b.WriteString("\n")
+
+	for d := 0; d < 7; d++ {
+		b.WriteString(s.subtle.Render(fmt.Sprintf("%-4s", calendarRowLabels[d])))
+		for _, week := range cal.Weeks {
+			day := week[d]
+			if day.Future {
+				b.WriteString(" ")
+				continue
+			}
+			b.WriteString(shades[day.Level].Render("■"))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n    ")
+	b.WriteString(s.subtle.Render("Less "))
+	for _, shade := range shades {
+		b.WriteString(shade.Render("■"))
+	}
+	b.WriteString(s.subtle.Render(" More"))
+	if cal.Active == 0 {
+		b.WriteString(s.subtle.Render("   No practice in the last year."))
+	} else {
+		b.WriteString(s.subtle.Render(fmt.Sprintf("   %d active days · best day %s", cal.Active, metric.Format(cal.Max))))
+		if metric != analytics.CalendarWPM {
+			b.WriteString(s.subtle.Render(" · total " + metric.Format(cal.Total)))
+		}
+	}
+	b.WriteString("\n\n")
+	return b.String()
+}
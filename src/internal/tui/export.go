@@ -0,0 +1,327 @@
+package tui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gti/src/internal/analytics"
+	"gti/src/internal/session"
+)
+
+type ExportFormat string
+
+const (
+	ExportJSON     ExportFormat = "json"
+	ExportCSV      ExportFormat = "csv"
+	ExportMarkdown ExportFormat = "md"
+	ExportHTML     ExportFormat = "html"
+)
+
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch strings.ToLower(name) {
+	case "json":
+		return ExportJSON, nil
+	case "csv":
+		return ExportCSV, nil
+	case "md", "markdown":
+		return ExportMarkdown, nil
+	case "html", "htm":
+		return ExportHTML, nil
+	default:
+		return "", fmt.Errorf("invalid export format '%s'. Valid options: csv, md, html, json", name)
+	}
+}
+
+// ExportFormatForPath guesses the format from a file extension, falling back
+// to JSON.
+func ExportFormatForPath(path string) ExportFormat {
+	if format, err := ParseExportFormat(strings.TrimPrefix(filepath.Ext(path), ".")); err == nil {
+		return format
+	}
+	return ExportJSON
+}
+
+// DefaultExportPath is a timestamped file in ~/Downloads, or the home
+// directory when there is no Downloads folder.
+func DefaultExportPath(view StatisticsView, format ExportFormat) string {
+	name := fmt.Sprintf("gti_statistics_%s_%s.%s", view, time.Now().Format("2006-01-02_15-04-05"), format)
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	downloads := filepath.Join(homeDir, "Downloads")
+	if info, err := os.Stat(downloads); err == nil && info.IsDir() {
+		return filepath.Join(downloads, name)
+	}
+	return filepath.Join(homeDir, name)
+}
+
+// ExportStatistics writes the records of a view, and the statistics over
+// them, to path. Records are expected newest first and already narrowed by
+// filter, which is only recorded in the export.
+func ExportStatistics(path string, format ExportFormat, view StatisticsView, filter analytics.Filter, records []*session.SessionRecord) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	stats := analytics.Calculate(records)
+	switch format {
+	case ExportCSV:
+		err = writeExportCSV(file, records)
+	case ExportMarkdown:
+		err = writeExportMarkdown(file, view, filter, stats, records)
+	case ExportHTML:
+		err = writeExportHTML(file, view, filter, stats, records)
+	default:
+		err = writeExportJSON(file, view, filter, stats, records)
+	}
+
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func writeExportJSON(w io.Writer, view StatisticsView, filter analytics.Filter, stats *analytics.Statistics, records []*session.SessionRecord) error {
+	exportData := map[string]interface{}{
+		"exported_at":   time.Now().Format(time.RFC3339),
+		"view":          string(view),
+		"statistics":    stats,
+		"session_count": len(records),
+		"sessions":      records,
+	}
+	if !filter.IsEmpty() {
+		exportData["filter"] = filter.String()
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exportData)
+}
+
+func writeExportCSV(w io.Writer, records []*session.SessionRecord) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"timestamp", "mode", "language", "text_source", "duration_s", "target_s", "characters", "wpm", "net_wpm", "cpm",
+		"accuracy", "mistakes", "corrected_errors", "uncorrected_errors", "backspaces", "valid",
+	})
+
+	number := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, r := range records {
+		writer.Write([]string{
+			r.Timestamp.Format(time.RFC3339),
+			r.Mode,
+			r.Language,
+			r.TextSource,
+			number(float64(r.DurationMs) / 1000),
+			number(float64(r.DurationTargetMs) / 1000),
+			strconv.Itoa(r.TextLength),
+			number(r.WPM),
+			number(r.NetWPM),
+			number(r.CPM),
+			number(r.Accuracy),
+			strconv.Itoa(r.Mistakes),
+			strconv.Itoa(r.CorrectedErrors),
+			strconv.Itoa(r.UncorrectedErrors),
+			strconv.Itoa(r.BackspaceCount),
+			strconv.FormatBool(session.IsValidRecord(r)),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+type exportSummaryRow struct {
+	Label string
+	Value string
+}
+
+func exportSummary(stats *analytics.Statistics) []exportSummaryRow {
+	return []exportSummaryRow{
+		{"Sessions", fmt.Sprintf("%d (%d valid)", stats.TotalSessions, len(stats.ValidSessions))},
+		{"Total time", formatDuration(stats.TotalTime)},
+		{"Average WPM", fmt.Sprintf("%.1f", stats.NormalizedAvgWPM)},
+		{"Peak WPM", fmt.Sprintf("%.1f", stats.NormalizedPeakWPM)},
+		{"Average net WPM", fmt.Sprintf("%.1f", stats.NetAvgWPM)},
+		{"Average accuracy", fmt.Sprintf("%.1f%%", stats.RawAvgAccuracy)},
+		{"Best accuracy", fmt.Sprintf("%.1f%%", stats.RawBestAccuracy)},
+		{"Consistency", fmt.Sprintf("%.1f%%", stats.ConsistencyScore)},
+		{"Current streak", fmt.Sprintf("%d days", stats.CurrentStreak)},
+		{"Longest streak", fmt.Sprintf("%d days", stats.LongestStreak)},
+	}
+}
+
+func writeExportMarkdown(w io.Writer, view StatisticsView, filter analytics.Filter, stats *analytics.Statistics, records []*session.SessionRecord) error {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# GTI Typing Statistics (%s)\n\n", view))
+	b.WriteString(fmt.Sprintf("Exported %s. Averages use sessions of at least %.0fs and %d characters.\n\n",
+		time.Now().Format("2006-01-02 15:04"), session.MinValidDuration.Seconds(), session.MinValidTextLength))
+	if !filter.IsEmpty() {
+		b.WriteString(fmt.Sprintf("Filter: `%s`\n\n", filter.String()))
+	}
+
+	b.WriteString("## Summary\n\n| Metric | Value |\n| --- | ---: |\n")
+	for _, row := range exportSummary(stats) {
+		b.WriteString(fmt.Sprintf("| %s | %s |\n", row.Label, row.Value))
+	}
+
+	b.WriteString("\n## Sessions\n\n")
+	b.WriteString("| Date | Mode | Language | Duration | WPM | Net WPM | Accuracy | Mistakes |\n")
+	b.WriteString("| --- | --- | --- | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, r := range records {
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %.1fs | %.1f | %.1f | %.1f%% | %d |\n",
+			r.Timestamp.Local().Format("2006-01-02 15:04"),
+			markdownEscape(r.Mode),
+			markdownEscape(r.Language),
+			float64(r.DurationMs)/1000,
+			r.WPM,
+			r.NetWPM,
+			r.Accuracy,
+			r.Mistakes,
+		))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+const (
+	chartWidth   = 640
+	chartHeight  = 220
+	chartPadding = 36
+)
+
+// svgChart draws a line chart of values, oldest on the left. It returns
+// plain SVG markup that needs no scripts or external files.
+func svgChart(title string, values []float64, unit string, color string) template.HTML {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`<svg viewBox="0 0 %d %d" width="100%%" role="img" aria-label="%s">`,
+		chartWidth, chartHeight, template.HTMLEscapeString(title)))
+
+	if len(values) == 0 {
+		b.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="muted">No valid sessions</text></svg>`, chartWidth/2, chartHeight/2))
+		return template.HTML(b.String())
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if hi-lo < 1 {
+		lo, hi = lo-1, hi+1
+	}
+
+	plotW := float64(chartWidth - 2*chartPadding)
+	plotH := float64(chartHeight - 2*chartPadding)
+	x := func(i int) float64 {
+		if len(values) == 1 {
+			return chartPadding + plotW/2
+		}
+		return chartPadding + plotW*float64(i)/float64(len(values)-1)
+	}
+	y := func(v float64) float64 {
+		return chartPadding + plotH*(1-(v-lo)/(hi-lo))
+	}
+
+	for _, v := range []float64{lo, (lo + hi) / 2, hi} {
+		b.WriteString(fmt.Sprintf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grid"/>`, chartPadding, y(v), chartWidth-chartPadding, y(v)))
+		b.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" class="muted">%.0f%s</text>`, chartPadding-6, y(v)+4, v, unit))
+	}
+
+	points := make([]string, len(values))
+	for i, v := range values {
+		points[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(v))
+	}
+	b.WriteString(fmt.Sprintf(`<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`, color, strings.Join(points, " ")))
+	for i, v := range values {
+		b.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%.1f%s</title></circle>`, x(i), y(v), color, v, unit))
+	}
+
+	b.WriteString("</svg>")
+	return template.HTML(b.String())
+}
+
+var exportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"seconds": func(ms int64) float64 { return float64(ms) / 1000 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>GTI Typing Statistics ({{.View}})</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 760px; margin: 2rem auto; padding: 0 1rem; color: #222; }
+h1 { margin-bottom: 0.2rem; }
+.muted { color: #777; fill: #777; font-size: 12px; }
+.cards { display: grid; grid-template-columns: repeat(auto-fill, minmax(170px, 1fr)); gap: 0.6rem; margin: 1rem 0; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: 0.6rem 0.8rem; }
+.card b { display: block; font-size: 1.3rem; }
+.grid { stroke: #e4e4e4; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9rem; }
+th, td { padding: 0.3rem 0.5rem; border-bottom: 1px solid #eee; text-align: right; }
+th:nth-child(-n+3), td:nth-child(-n+3) { text-align: left; }
+</style>
+</head>
+<body>
+<h1>GTI Typing Statistics</h1>
+<p class="muted">{{.View}} view{{with .Filter}} · filter {{.}}{{end}} · exported {{.Exported}} · averages use sessions of at least {{.MinSeconds}}s and {{.MinChars}} characters</p>
+<div class="cards">
+{{range .Summary}}<div class="card"><span class="muted">{{.Label}}</span><b>{{.Value}}</b></div>
+{{end}}</div>
+<h2>WPM trend</h2>
+{{.WPMChart}}
+<h2>Accuracy trend</h2>
+{{.AccuracyChart}}
+<h2>Sessions</h2>
+<table>
+<tr><th>Date</th><th>Mode</th><th>Language</th><th>Duration</th><th>WPM</th><th>Net WPM</th><th>Accuracy</th><th>Mistakes</th></tr>
+{{range .Sessions}}<tr><td>{{.Timestamp.Local.Format "2006-01-02 15:04"}}</td><td>{{.Mode}}</td><td>{{.Language}}</td><td>{{printf "%.1f" (seconds .DurationMs)}}s</td><td>{{printf "%.1f" .WPM}}</td><td>{{printf "%.1f" .NetWPM}}</td><td>{{printf "%.1f" .Accuracy}}%</td><td>{{.Mistakes}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func writeExportHTML(w io.Writer, view StatisticsView, filter analytics.Filter, stats *analytics.Statistics, records []*session.SessionRecord) error {
+	// Charts run oldest to newest over the sessions that count.
+	valid := stats.ValidSessions
+	wpms := make([]float64, len(valid))
+	accuracies := make([]float64, len(valid))
+	for i, r := range valid {
+		wpms[len(valid)-1-i] = r.WPM
+		accuracies[len(valid)-1-i] = r.Accuracy
+	}
+
+	return exportHTMLTemplate.Execute(w, map[string]interface{}{
+		"View":          string(view),
+		"Filter":        filter.String(),
+		"Exported":      time.Now().Format("2006-01-02 15:04"),
+		"MinSeconds":    session.MinValidDuration.Seconds(),
+		"MinChars":      session.MinValidTextLength,
+		"Summary":       exportSummary(stats),
+		"WPMChart":      svgChart("WPM trend", wpms, "", "#0077cc"),
+		"AccuracyChart": svgChart("Accuracy trend", accuracies, "%", "#2e9e44"),
+		"Sessions":      records,
+	})
+}
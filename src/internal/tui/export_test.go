@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gti/src/internal/analytics"
+	"gti/src/internal/session"
+)
+
+func TestExportFormatForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want ExportFormat
+	}{
+		{"stats.csv", ExportCSV},
+		{"stats.MD", ExportMarkdown},
+		{"report.markdown", ExportMarkdown},
+		{"dir/report.htm", ExportHTML},
+		{"stats.json", ExportJSON},
+		{"stats.txt", ExportJSON},
+		{"stats", ExportJSON},
+	}
+
+	for _, tt := range tests {
+		if got := ExportFormatForPath(tt.path); got != tt.want {
+			t.Errorf("ExportFormatForPath(%q) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+	if _, err := ParseExportFormat("pdf"); err == nil {
+		t.Error("ParseExportFormat accepted pdf")
+	}
+}
+
+func exportRecords() []*session.SessionRecord {
+	at := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	return []*session.SessionRecord{
+		{Timestamp: at, Mode: "custom", Language: "a|b", TextSource: "file:<notes>", DurationMs: 30000, TextLength: 250, WPM: 60, NetWPM: 58, Accuracy: 97.5, Mistakes: 3},
+		{Timestamp: at.Add(-time.Hour), Mode: "timed", Language: "english", DurationMs: 5000, TextLength: 40, WPM: 80, Accuracy: 100},
+	}
+}
+
+func TestExportStatistics(t *testing.T) {
+	filter := analytics.Filter{Mode: "custom"}
+
+	tests := []struct {
+		format ExportFormat
+		check  func(t *testing.T, content string)
+	}{
+		{
+			format: ExportJSON,
+			check: func(t *testing.T, content string) {
+				var data struct {
+					View         string                   `json:"view"`
+					Filter       string                   `json:"filter"`
+					SessionCount int                      `json:"session_count"`
+					Sessions     []*session.SessionRecord `json:"sessions"`
+				}
+				if err := json.Unmarshal([]byte(content), &data); err != nil {
+					t.Fatal(err)
+				}
+				if data.View != "weekly" || data.Filter != "mode:custom" || data.SessionCount != 2 || len(data.Sessions) != 2 {
+					t.Errorf("json = %+v, want the weekly view, its filter and 2 sessions", data)
+				}
+			},
+		},
+		{
+			format: ExportCSV,
+			check: func(t *testing.T, content string) {
+				rows, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rows) != 3 || rows[0][0] != "timestamp" {
+					t.Fatalf("csv has %d rows, want a header and 2 sessions", len(rows))
+				}
+				first, short := rows[1], rows[2]
+				if first[2] != "a|b" || first[3] != "file:<notes>" || first[4] != "30.00" || first[7] != "60.00" || first[15] != "true" {
+					t.Errorf("first row = %v", first)
+				}
+				if short[15] != "false" {
+					t.Errorf("a 5s session is marked valid: %v", short)
+				}
+			},
+		},
+		{
+			format: ExportMarkdown,
+			check: func(t *testing.T, content string) {
+				for _, want := range []string{"# GTI Typing Statistics (weekly)", "Filter: `mode:custom`", `| custom | a\|b | 30.0s | 60.0 | 58.0 | 97.5% | 3 |`} {
+					if !strings.Contains(content, want) {
+						t.Errorf("markdown is missing %q", want)
+					}
+				}
+			},
+		},
+		{
+			format: ExportHTML,
+			check: func(t *testing.T, content string) {
+				if !strings.Contains(content, "filter mode:custom") || strings.Count(content, "<svg") != 2 {
+					t.Error("html is missing the filter or its two charts")
+				}
+				if !strings.Contains(content, "<td>a|b</td>") || !strings.Contains(content, "<td>60.0</td>") {
+					t.Error("html is missing the session row")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "exports", "stats."+string(tt.format))
+			if err := ExportStatistics(path, tt.format, analytics.ViewWeekly, filter, exportRecords()); err != nil {
+				t.Fatal(err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, string(content))
+		})
+	}
+}
+
+func TestSVGChart(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		points int
+	}{
+		{name: "no sessions"},
+		{name: "one session", values: []float64{50}, points: 1},
+		{name: "flat line", values: []float64{50, 50, 50}, points: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := string(svgChart("WPM <trend>", tt.values, "", "#000"))
+			if !strings.HasPrefix(chart, "<svg") || !strings.HasSuffix(chart, "</svg>") {
+				t.Errorf("chart is not a complete svg: %s", chart)
+			}
+			if strings.Contains(chart, "<trend>") {
+				t.Error("chart title is not escaped")
+			}
+			if got := strings.Count(chart, "<circle"); got != tt.points {
+				t.Errorf("chart has %d points, want %d", got, tt.points)
+			}
+			if strings.Contains(chart, "NaN") {
+				t.Error("chart has NaN coordinates")
+			}
+		})
+	}
+}
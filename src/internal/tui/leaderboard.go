@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gti/src/internal/config"
+	"gti/src/internal/leaderboard"
+	"gti/src/internal/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type LeaderboardModel struct {
+	dir     string
+	players []*leaderboard.Player
+	modes   []string
+	mode    int
+	window  int
+	board   leaderboard.Board
+	width   int
+	height  int
+	styles  statsStyles
+}
+
+func NewLeaderboardModel(cfg *config.Config, dir string, players []*leaderboard.Player, mode string, window leaderboard.Window) LeaderboardModel {
+	m := LeaderboardModel{
+		dir:     dir,
+		players: players,
+		modes:   leaderboard.Modes(players),
+		window:  len(leaderboard.Windows) - 1,
+		styles:  newStatsStyles(cfg),
+	}
+	for i, name := range m.modes {
+		if name == mode {
+			m.mode = i
+		}
+	}
+	for i, w := range leaderboard.Windows {
+		if w == window {
+			m.window = i
+		}
+	}
+	m.rank()
+	return m
+}
+
+func (m *LeaderboardModel) rank() {
+	m.board = leaderboard.Rank(m.players, m.modes[m.mode], leaderboard.Windows[m.window], time.Now())
+}
+
+func (m LeaderboardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *LeaderboardModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "h", "left":
+		m.window = (m.window + len(leaderboard.Windows) - 1) % len(leaderboard.Windows)
+		m.rank()
+	case "l", "right":
+		m.window = (m.window + 1) % len(leaderboard.Windows)
+		m.rank()
+	case "k", "up", "shift+tab":
+		m.mode = (m.mode + len(m.modes) - 1) % len(m.modes)
+		m.rank()
+	case "j", "down", "tab", "m":
+		m.mode = (m.mode + 1) % len(m.modes)
+		m.rank()
+	}
+	return m, nil
+}
+
+func (m LeaderboardModel) View() string {
+	s := m.styles
+	var b strings.Builder
+
+	b.WriteString(s.title.Render("🏆 GTI TEAM LEADERBOARD 🏆"))
+	b.WriteString("\n")
+	b.WriteString(s.subtle.Render(fmt.Sprintf("%s · %d players", m.dir, len(m.players))))
+	b.WriteString("\n\n")
+
+	var windows []string
+	for i, w := range leaderboard.Windows {
+		if i == m.window {
+			windows = append(windows, s.viewOn.Render("> "+strings.ToUpper(w.Label())))
+		} else {
+			windows = append(windows, s.viewOff.Render("  "+strings.ToUpper(w.Label())))
+		}
+	}
+	b.WriteString(strings.Join(windows, " "))
+	b.WriteString("\n")
+	b.WriteString(s.key.Render("Mode: "))
+	b.WriteString(s.accent.Render(m.modes[m.mode]))
+	b.WriteString(s.subtle.Render(fmt.Sprintf("  (%d/%d)", m.mode+1, len(m.modes))))
+	b.WriteString("\n\n")
+
+	b.WriteString(s.section.Render(fmt.Sprintf("%-5s %-20s %9s %9s %9s %9s", "RANK", "PLAYER", "AVG WPM", "BEST WPM", "ACCURACY", "SESSIONS")))
+	b.WriteString("\n")
+	b.WriteString(s.subtle.Render(strings.Repeat("─", 66)))
+	b.WriteString("\n")
+
+	if len(m.board.Entries) == 0 {
+		b.WriteString(s.subtle.Render("No valid sessions in this window."))
+		b.WriteString("\n")
+	}
+	for _, e := range m.board.Entries {
+		rank := fmt.Sprintf("#%d", e.Rank)
+		switch e.Rank {
+		case 1:
+			rank = "🥇"
+		case 2:
+			rank = "🥈"
+		case 3:
+			rank = "🥉"
+		}
+		name := e.Player
+		if len(name) > 20 {
+			name = name[:20]
+		}
+		line := fmt.Sprintf("%-5s %-20s %9.1f %9.1f %8.1f%% %9d", rank, name, e.AvgWPM, e.BestWPM, e.AvgAccuracy, e.Sessions)
+		if e.Rank == 1 {
+			b.WriteString(s.good.Render(line))
+		} else {
+			b.WriteString(s.val.Render(line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.subtle.Render(fmt.Sprintf("Ranked by average WPM over sessions of at least %.0fs and %d characters.",
+		session.MinValidDuration.Seconds(), session.MinValidTextLength)))
+	b.WriteString("\n\n")
+	b.WriteString(s.footer.Render("[h/l] Window   [j/k] Mode   [q] Quit"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+}
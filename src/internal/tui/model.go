@@ -29,6 +29,10 @@ type Model struct {
 	quitting  bool
 	width     int
 	height    int
+
+	// resultsDetail shows the detail pane of the results screen instead
+	// of the summary.
+	resultsDetail bool
 }
 
 type ModelOptions struct {
@@ -95,6 +99,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case session.SessionCompleteMsg:
 		m.mode = ModeResults
+		m.resultsDetail = false
 		return m, nil
 	case session.TimerTickMsg:
 		return m, m.sess.UpdateTimer()
@@ -130,6 +135,10 @@ func (m *Model) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case ModeResults:
+		if key.String() == "tab" {
+			m.resultsDetail = !m.resultsDetail
+			return m, nil
+		}
 		if key.String() == "enter" {
 			m.mode = ModeTyping
 			return m, m.sess.Restart()
@@ -213,30 +222,44 @@ func (m Model) viewResults() string {
 	calculator := session.NewResultsCalculator()
 	results := calculator.CalculateResults(m.sess, m.sess.GetMode())
 
-	action := "Press Enter to restart or Esc to exit"
-
-	content := fmt.Sprintf(`Results
-
-WPM: %.1f
-Accuracy: %.1f%%
-CPM: %.1f
-Duration: %.2fs
-Mistakes: %d
+	styledContent := m.renderResultsSummary(results)
+	padding := []int{3, 4}
+	if m.resultsDetail {
+		styledContent = m.renderResultsDetail(results)
+		padding = []int{1, 2}
+	}
 
-%s`, results.WPM, results.Accuracy, results.CPM, results.Duration.Seconds(), results.Mistakes, action)
+	if best := m.sess.NewBest(); best != nil {
+		banner := fmt.Sprintf("First personal best for %s", best.Best.Category)
+		if best.Previous != nil {
+			banner = fmt.Sprintf("🏆 New personal best! 🏆\n%s: %.1f WPM (+%.1f over %.1f)",
+				best.Best.Category, best.Best.WPM, best.Delta(), best.Previous.WPM)
+		}
+		celebration := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.config.Theme.Colors.Correct)).
+			Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+			Bold(true).
+			Align(lipgloss.Center).
+			Render(banner)
+		styledContent = lipgloss.JoinVertical(lipgloss.Center, celebration, "", styledContent)
+	}
 
-	styledContent := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
-		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
-		Align(lipgloss.Center).
-		Render(content)
+	if err := m.sess.SaveError(); err != nil {
+		warning := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(m.config.Theme.Colors.Incorrect)).
+			Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+			Align(lipgloss.Center).
+			Width(50).
+			Render("This session was not saved to history:\n" + err.Error())
+		styledContent = lipgloss.JoinVertical(lipgloss.Center, styledContent, "", warning)
+	}
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
 		BorderBackground(lipgloss.Color(m.config.Theme.Colors.Background)).
 		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
-		Padding(3, 4).
+		Padding(padding...).
 		Render(styledContent)
 
 	placedBox := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box,
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// profilePicker asks which profile to use before any config is loaded, so
+// it sticks to terminal default colours instead of a theme.
+type profilePicker struct {
+	names    []string
+	cursor   int
+	chosen   string
+	canceled bool
+	width    int
+	height   int
+}
+
+func (m profilePicker) Init() tea.Cmd {
+	return nil
+}
+
+func (m profilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.canceled = true
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.names)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			m.chosen = m.names[m.cursor]
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m profilePicker) View() string {
+	var b strings.Builder
+	b.WriteString("Who's typing?\n\n")
+	for i, name := range m.names {
+		line := fmt.Sprintf("  %s", name)
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Reverse(true).Render(fmt.Sprintf("▶ %s", name))
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n↑/↓ select  Enter choose  Q quit")
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// PickProfile shows the startup profile picker. It returns ok=false when the
+// user quits without choosing.
+func PickProfile(names []string, current string) (string, bool, error) {
+	picker := profilePicker{names: names}
+	for i, name := range names {
+		if name == current {
+			picker.cursor = i
+		}
+	}
+
+	result, err := tea.NewProgram(picker, tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", false, err
+	}
+	final := result.(profilePicker)
+	if final.canceled {
+		return "", false, nil
+	}
+	return final.chosen, true, nil
+}
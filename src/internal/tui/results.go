@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"gti/src/internal/session"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	resultsChartHeight = 8
+	resultsMaxWords    = 12
+
+	resultsMaxConfusions = 8
+)
+
+func (m Model) renderResultsSummary(results session.Results) string {
+	consistency := "n/a"
+	if len(results.Timeline) >= 2 {
+		consistency = fmt.Sprintf("%.0f%%", results.Consistency)
+	}
+
+	content := fmt.Sprintf(`Results
+
+WPM: %.1f
+Accuracy: %.1f%%
+CPM: %.1f
+Duration: %.2fs
+Mistakes: %d
+Consistency: %s
+
+Press Tab for details, Enter to restart or Esc to exit`, results.WPM, results.Accuracy, results.CPM, results.Duration.Seconds(), results.Mistakes, consistency)
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.TextPrimary)).
+		Background(lipgloss.Color(m.config.Theme.Colors.Background)).
+		Align(lipgloss.Center).
+		Render(content)
+}
+
+func (m Model) renderResultsDetail(results session.Results) string {
+	colors := m.config.Theme.Colors
+	bg := lipgloss.Color(colors.Background)
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.TextPrimary)).Background(bg)
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.TextSecondary)).Background(bg)
+	title := text.Copy().Bold(true)
+
+	width := m.width - 12
+	if width > 76 {
+		width = 76
+	}
+
+	var lines []string
+	lines = append(lines, title.Render("Speed per second"), "")
+	if len(results.Timeline) == 0 {
+		lines = append(lines, subtle.Render("No keystrokes to chart."))
+	} else {
+		lines = append(lines, m.renderWPMChart(results.Timeline, width))
+		lines = append(lines, subtle.Render("· raw   ")+
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Correct)).Background(bg).Render("• net   ")+
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Incorrect)).Background(bg).Render("× error"))
+	}
+
+	consistency := "n/a"
+	if len(results.Timeline) >= 2 {
+		consistency = fmt.Sprintf("%.0f%%", results.Consistency)
+	}
+	lines = append(lines, "", title.Render("Errors"), "",
+		text.Render(fmt.Sprintf("Corrected: %d   Uncorrected: %d   Backspaces: %d   Consistency: %s",
+			results.CorrectedErrors, results.UncorrectedErrors, results.Backspaces, consistency)))
+
+	typos := results.Typos
+	lines = append(lines, "", text.Render(fmt.Sprintf("Typos left: %d substituted, %d inserted, %d omitted, %d transposed",
+		typos.Substitutions, typos.Insertions, typos.Omissions, typos.Transpositions)))
+	if len(typos.Confusions) > 0 {
+		lines = append(lines, text.Copy().Width(width).Render("Confused: "+session.FormatConfusions(typos.Confusions, resultsMaxConfusions)))
+	}
+
+	lines = append(lines, "", title.Render("Mistyped words"), "")
+	if len(results.MistypedWords) == 0 {
+		lines = append(lines, subtle.Render("None. Clean run!"))
+	} else {
+		lines = append(lines, text.Copy().Width(width).Render(formatMistypedWords(results.MistypedWords)))
+	}
+
+	lines = append(lines, "", subtle.Render("Press Tab for the summary, Enter to restart or Esc to exit"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatMistypedWords lists the most missed words first, with a count for
+// words missed more than once.
+func formatMistypedWords(words []session.MistypedWord) string {
+	sorted := make([]session.MistypedWord, len(words))
+	copy(sorted, words)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Count > sorted[j].Count
+	})
+
+	var parts []string
+	for i, w := range sorted {
+		if i == resultsMaxWords {
+			parts = append(parts, fmt.Sprintf("and %d more", len(sorted)-resultsMaxWords))
+			break
+		}
+		if w.Count > 1 {
+			parts = append(parts, fmt.Sprintf("%s ×%d", w.Word, w.Count))
+		} else {
+			parts = append(parts, w.Word)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderWPMChart draws raw and net WPM over the session, one column per
+// slice of time, with a row of markers under the seconds that had errors.
+func (m Model) renderWPMChart(timeline []session.SecondStat, width int) string {
+	colors := m.config.Theme.Colors
+	bg := lipgloss.Color(colors.Background)
+	axis := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.TextSecondary)).Background(bg)
+	styles := map[rune]lipgloss.Style{
+		' ': lipgloss.NewStyle().Background(bg),
+		'·': axis,
+		'•': lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Correct)).Background(bg),
+		'×': lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Incorrect)).Background(bg),
+	}
+
+	n := len(timeline)
+	cols := width - 6
+	if cols > n*4 {
+		cols = n * 4
+	}
+	if cols < 1 {
+		cols = 1
+	}
+
+	raw := make([]float64, cols)
+	net := make([]float64, cols)
+	errors := make([]int, cols)
+	top := 0.0
+	for c := 0; c < cols; c++ {
+		lo := c * n / cols
+		hi := (c + 1) * n / cols
+		if hi <= lo {
+			hi = lo + 1
+		}
+		for _, t := range timeline[lo:hi] {
+			raw[c] += t.RawWPM
+			net[c] += t.NetWPM
+			errors[c] += t.Errors
+		}
+		raw[c] /= float64(hi - lo)
+		net[c] /= float64(hi - lo)
+		top = math.Max(top, math.Max(raw[c], net[c]))
+	}
+	top = math.Max(10, math.Ceil(top/10)*10)
+
+	grid := make([][]rune, resultsChartHeight)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", cols))
+	}
+	plot := func(c int, value float64, mark rune) {
+		row := int(math.Round(value / top * float64(resultsChartHeight-1)))
+		grid[resultsChartHeight-1-row][c] = mark
+	}
+	for c := 0; c < cols; c++ {
+		plot(c, raw[c], '·')
+		plot(c, net[c], '•')
+	}
+
+	var b strings.Builder
+	for r, row := range grid {
+		label := "     │"
+		switch r {
+		case 0:
+			label = fmt.Sprintf("%4.0f ┤", top)
+		case resultsChartHeight / 2:
+			label = fmt.Sprintf("%4.0f ┤", top*float64(resultsChartHeight-1-r)/float64(resultsChartHeight-1))
+		case resultsChartHeight - 1:
+			label = fmt.Sprintf("%4.0f ┤", 0.0)
+		}
+		b.WriteString(axis.Render(label))
+		b.WriteString(renderRuns(row, styles))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(axis.Render("     └" + strings.Repeat("─", cols)))
+	b.WriteString("\n")
+
+	markers := []rune(strings.Repeat(" ", cols))
+	for c, e := range errors {
+		if e > 0 {
+			markers[c] = '×'
+		}
+	}
+	b.WriteString(axis.Render("      "))
+	b.WriteString(renderRuns(markers, styles))
+	b.WriteString("\n")
+
+	end := fmt.Sprintf("%ds", timeline[n-1].Second)
+	gap := cols - 2 - len(end)
+	if gap < 1 {
+		gap = 1
+	}
+	b.WriteString(axis.Render("      0s" + strings.Repeat(" ", gap) + end))
+	return b.String()
+}
+
+// renderRuns styles a row of chart cells, one style change per run of equal
+// cells.
+func renderRuns(row []rune, styles map[rune]lipgloss.Style) string {
+	var b strings.Builder
+	for start := 0; start < len(row); {
+		end := start
+		for end < len(row) && row[end] == row[start] {
+			end++
+		}
+		b.WriteString(styles[row[start]].Render(string(row[start:end])))
+		start = end
+	}
+	return b.String()
+}
@@ -1,31 +1,23 @@
 package tui
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"gti/src/internal/analytics"
 	"gti/src/internal/config"
 	"gti/src/internal/session"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 const (
-	minValidDuration   = 15 * time.Second
-	minValidTextLength = 60
-
-	recentSessionsCount       = 5
-	minSessionsForVariance    = 3
-	minSessionsForImprovement = 10
-
 	lowAccuracyThreshold            = 85.0
 	highVarianceThreshold           = 25.0
 	goodVarianceThreshold           = 12.0
@@ -62,65 +54,44 @@ const (
 	consistencyVarianceThreshold = 10.0
 )
 
-type StatisticsView string
+type StatisticsView = analytics.View
 
 const (
-	ViewAllTime StatisticsView = "all-time"
-	ViewWeekly  StatisticsView = "weekly"
-	ViewDaily   StatisticsView = "daily"
-	ViewSession StatisticsView = "session"
+	ViewAllTime = analytics.ViewAllTime
+	ViewWeekly  = analytics.ViewWeekly
+	ViewDaily   = analytics.ViewDaily
+	ViewSession = analytics.ViewSession
 )
 
 type StatisticsModel struct {
 	config   *config.Config
 	view     StatisticsView
 	records  []*session.SessionRecord
-	stats    *Statistics
+	stats    *analytics.Statistics
 	width    int
 	height   int
 	quitting bool
+	report   *session.LoadReport
+	bests    []session.PersonalBest
+
+	notice    string
+	noticeErr bool
+
+	filter      analytics.Filter
+	filterInput textinput.Model
+	filtering   bool
+
+	calendarMetric int
 
 	cachedView            StatisticsView
 	cachedFilteredRecords []*session.SessionRecord
-	cachedFilteredStats   *Statistics
+	cachedFilteredStats   *analytics.Statistics
 
 	viewport viewport.Model
 
 	styles statsStyles
 }
 
-type Statistics struct {
-	TotalSessions   int
-	TotalTime       time.Duration
-	RawAvgWPM       float64
-	RawPeakWPM      float64
-	RawAvgAccuracy  float64
-	RawBestAccuracy float64
-	AvgMistakes     float64
-	BackspaceRate   float64
-
-	ValidSessions        []*session.SessionRecord
-	NormalizedAvgWPM     float64
-	NormalizedPeakWPM    float64
-	RecentValidAvgWPM    float64
-	RecentValidCountUsed int
-
-	NetAvgWPM            float64
-	NetPeakWPM           float64
-	AdjustedAvgWPM       float64
-	AdjustedPeakWPM      float64
-	AvgCorrectedErrors   float64
-	AvgUncorrectedErrors float64
-
-	ConsistencyScore float64
-	ImprovementRate  float64
-	VariancePercent  float64
-	OutlierCount     int
-
-	CurrentStreak int
-	LongestStreak int
-}
-
 type statsStyles struct {
 	base      lipgloss.Style
 	title     lipgloss.Style
@@ -138,16 +109,26 @@ type statsStyles struct {
 	monoWidth int
 }
 
-func NewStatisticsModel(cfg *config.Config) StatisticsModel {
-	records, _ := session.LoadSessionRecords(cfg)
+func NewStatisticsModel(cfg *config.Config, view StatisticsView, filter analytics.Filter) StatisticsModel {
+	records, report, _ := session.LoadHistory(cfg)
 
 	m := StatisticsModel{
 		config:  cfg,
-		view:    ViewAllTime,
+		view:    view,
 		records: records,
-		stats:   calculateStatistics(records),
+		stats:   analytics.Calculate(records),
+		report:  report,
+		filter:  filter,
 	}
 	m.styles = newStatsStyles(cfg)
+	if bests, err := session.LoadPersonalBests(cfg); err == nil {
+		m.bests = session.SortedBests(bests)
+	}
+
+	m.filterInput = textinput.New()
+	m.filterInput.Prompt = "Filter: "
+	m.filterInput.Placeholder = "mode:words lang:go from:2024-05-01 to:2024-05-31 last:30d"
+	m.filterInput.CharLimit = 200
 
 	m.viewport = viewport.New(80, 20)
 	m.viewport.SetContent(m.renderScrollableContent())
@@ -264,12 +245,16 @@ func (m StatisticsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.filtering {
+			return m.handleFilterKey(msg)
+		}
 		return m.handleKey(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.filterInput.Width = m.width - len(m.filterInput.Prompt) - 1
 
-		headerHeight := 4
+		headerHeight := 5
 		footerHeight := 1
 		viewportHeight := m.height - headerHeight - footerHeight
 		if viewportHeight < 10 {
@@ -282,6 +267,10 @@ func (m StatisticsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.filtering {
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 	m.viewport, cmd = m.viewport.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -302,11 +291,19 @@ func (m StatisticsModel) View() string {
 
 	header := centerText("🚀 GTI TYPING STATISTICS 🚀", width) + "\n"
 	header += strings.Repeat("─", width) + "\n"
-	header += m.renderViewSelector() + "\n\n"
+	header += m.renderViewSelector() + "\n"
+	header += m.renderFilterBar() + "\n\n"
 
 	viewportContent := m.viewport.View()
 
-	footer := "\n" + s.footer.Render("[q] Quit   [s] Switch View   [h/l] Navigate   [e] Export   [↑/↓] Scroll   [PgUp/PgDn] Page")
+	footer := "\n" + s.footer.Render("[q] Quit   [s] Switch View   [h/l] Navigate   [f] Filter   [e] Export   [↑/↓] Scroll   [PgUp/PgDn] Page")
+	if m.notice != "" {
+		style := s.good
+		if m.noticeErr {
+			style = s.bad
+		}
+		footer = "\n" + style.Render(m.notice)
+	}
 
 	content := header + viewportContent + footer
 
@@ -314,6 +311,7 @@ func (m StatisticsModel) View() string {
 }
 
 func (m *StatisticsModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
 	switch key.String() {
 	case "q", "ctrl+c":
 		m.quitting = true
@@ -327,6 +325,15 @@ func (m *StatisticsModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "l":
 		m.nextView()
 		return m, nil
+	case "f", "/":
+		m.filtering = true
+		m.filterInput.SetValue(m.filter.String())
+		m.filterInput.CursorEnd()
+		return m, m.filterInput.Focus()
+	case "c":
+		m.calendarMetric = (m.calendarMetric + 1) % len(analytics.CalendarMetrics)
+		m.viewport.SetContent(m.renderScrollableContent())
+		return m, nil
 	case "e":
 		m.exportStatistics()
 		return m, nil
@@ -346,45 +353,55 @@ func (m *StatisticsModel) handleKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *StatisticsModel) exportStatistics() {
-	stats := m.getFilteredStats()
-	records := m.getFilteredRecords()
-
-	exportData := map[string]interface{}{
-		"exported_at":   time.Now().Format(time.RFC3339),
-		"view":          string(m.view),
-		"statistics":    stats,
-		"session_count": len(records),
-		"sessions":      records,
-	}
-
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	filename := fmt.Sprintf("gti_statistics_%s_%s.json", m.view, timestamp)
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
-
-	exportDir := filepath.Join(homeDir, "Downloads")
-	if err := os.MkdirAll(exportDir, 0755); err != nil {
-		exportDir = homeDir
+// handleFilterKey edits the filter bar. Enter applies the filter, or clears
+// it when the bar is empty; Esc leaves the current filter in place.
+func (m *StatisticsModel) handleFilterKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+	switch key.String() {
+	case "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc":
+		m.filtering = false
+		m.filterInput.Blur()
+		return m, nil
+	case "enter":
+		filter, err := analytics.ParseFilter(m.filterInput.Value())
+		if err != nil {
+			m.notice = err.Error()
+			m.noticeErr = true
+			return m, nil
+		}
+		m.filter = filter
+		m.filtering = false
+		m.filterInput.Blur()
+		m.refresh()
+		return m, nil
 	}
 
-	filePath := filepath.Join(exportDir, filename)
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(key)
+	return m, cmd
+}
 
-	file, err := os.Create(filePath)
-	if err != nil {
-		return
-	}
-	defer file.Close()
+// refresh recalculates the current view after the view or filter changed.
+func (m *StatisticsModel) refresh() {
+	m.cachedView = m.view
+	m.cachedFilteredRecords = m.getFilteredRecords()
+	m.cachedFilteredStats = analytics.Calculate(m.cachedFilteredRecords)
+	m.viewport.SetContent(m.renderScrollableContent())
+	m.viewport.GotoTop()
+}
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(exportData); err != nil {
+func (m *StatisticsModel) exportStatistics() {
+	path := DefaultExportPath(m.view, ExportJSON)
+	if err := ExportStatistics(path, ExportJSON, m.view, m.filter, m.getFilteredRecords()); err != nil {
+		m.notice = "Export failed: " + err.Error()
+		m.noticeErr = true
 		return
 	}
-
+	m.notice = "Exported to " + path
+	m.noticeErr = false
 }
 
 func (m *StatisticsModel) switchView() {
@@ -400,11 +417,7 @@ func (m *StatisticsModel) switchView() {
 	}
 
 	if m.cachedView != m.view {
-		m.cachedView = m.view
-		m.cachedFilteredRecords = m.getFilteredRecords()
-		m.cachedFilteredStats = calculateStatistics(m.cachedFilteredRecords)
-		m.viewport.SetContent(m.renderScrollableContent())
-		m.viewport.GotoTop()
+		m.refresh()
 	}
 }
 
@@ -423,65 +436,22 @@ func (m *StatisticsModel) previousView() {
 	}
 
 	if m.cachedView != m.view {
-		m.cachedView = m.view
-		m.cachedFilteredRecords = m.getFilteredRecords()
-		m.cachedFilteredStats = calculateStatistics(m.cachedFilteredRecords)
-		m.viewport.SetContent(m.renderScrollableContent())
-		m.viewport.GotoTop()
+		m.refresh()
 	}
 }
 
 func (m StatisticsModel) getFilteredRecords() []*session.SessionRecord {
-	now := time.Now()
-
-	switch m.view {
-	case ViewSession:
-		if len(m.records) > 0 {
-			return []*session.SessionRecord{m.records[0]}
-		}
-		return []*session.SessionRecord{}
-
-	case ViewDaily:
-		var daily []*session.SessionRecord
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		for _, r := range m.records {
-			if !r.Timestamp.Before(today) {
-				daily = append(daily, r)
-			}
-		}
-		return daily
-
-	case ViewWeekly:
-		var weekly []*session.SessionRecord
-		daysSinceMonday := int(now.Weekday() - time.Monday)
-		if daysSinceMonday < 0 {
-			daysSinceMonday += 7
-		}
-		monday := now.AddDate(0, 0, -daysSinceMonday)
-		monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
-
-		for _, r := range m.records {
-			if !r.Timestamp.Before(monday) {
-				weekly = append(weekly, r)
-			}
-		}
-		return weekly
-
-	case ViewAllTime:
-		fallthrough
-	default:
-		return m.records
-	}
+	return analytics.FilterView(m.filter.Apply(m.records), m.view)
 }
 
-func (m StatisticsModel) getFilteredStats() *Statistics {
-	return calculateStatistics(m.getFilteredRecords())
+func (m StatisticsModel) getFilteredStats() *analytics.Statistics {
+	return analytics.Calculate(m.getFilteredRecords())
 }
 
 func (m StatisticsModel) renderScrollableContent() string {
 	var b strings.Builder
 
-	var filteredStats *Statistics
+	var filteredStats *analytics.Statistics
 	var filteredRecords []*session.SessionRecord
 
 	if m.cachedView == m.view && m.cachedFilteredStats != nil {
@@ -496,6 +466,16 @@ func (m StatisticsModel) renderScrollableContent() string {
 
 	b.WriteString(m.renderPerformanceAnalysisWithStats(filteredStats))
 
+	b.WriteString(m.renderBreakdownWithStats(filteredStats))
+
+	b.WriteString(m.renderTyposWithStats(filteredStats))
+
+	b.WriteString(m.renderGoals())
+
+	b.WriteString(m.renderCalendar())
+
+	b.WriteString(m.renderPersonalBests())
+
 	b.WriteString(m.renderAchievements())
 
 	b.WriteString(m.renderRecentSessionsWithRecords(filteredRecords))
@@ -528,6 +508,11 @@ func (m StatisticsModel) renderStatistics() string {
 
 	b.WriteString(m.renderStatisticsSummaryWithStats(filteredStats))
 	b.WriteString(m.renderPerformanceAnalysisWithStats(filteredStats))
+	b.WriteString(m.renderBreakdownWithStats(filteredStats))
+	b.WriteString(m.renderTyposWithStats(filteredStats))
+	b.WriteString(m.renderGoals())
+	b.WriteString(m.renderCalendar())
+	b.WriteString(m.renderPersonalBests())
 	b.WriteString(m.renderAchievements())
 	b.WriteString(m.renderRecentSessionsWithRecords(filteredRecords))
 
@@ -567,7 +552,18 @@ func (m StatisticsModel) renderViewSelector() string {
 	return strings.Join(parts, " ")
 }
 
-func (m StatisticsModel) renderStatisticsSummaryWithStats(stats *Statistics) string {
+func (m StatisticsModel) renderFilterBar() string {
+	s := m.styles
+	if m.filtering {
+		return m.filterInput.View()
+	}
+	if m.filter.IsEmpty() {
+		return s.subtle.Render("Filter: none")
+	}
+	return s.key.Render("Filter: ") + s.accent.Render(m.filter.String())
+}
+
+func (m StatisticsModel) renderStatisticsSummaryWithStats(stats *analytics.Statistics) string {
 	s := m.styles
 	var b strings.Builder
 
@@ -591,10 +587,14 @@ func (m StatisticsModel) renderStatisticsSummaryWithStats(stats *Statistics) str
 			s.val.Render(fmt.Sprintf("%d (short/low-text)", stats.OutlierCount)),
 		))
 	}
+	if m.report.HasProblems() {
+		b.WriteString(s.bad.Render(fmt.Sprintf("%d unreadable lines in history were skipped. Run 'gti history migrate' to review them.", len(m.report.Unreadable))))
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
 
 	if len(stats.ValidSessions) > 0 {
-		b.WriteString(fmt.Sprintf("%s (>=%.0fs and >=%d chars):", s.key.Render("Normalized WPM"), minValidDuration.Seconds(), minValidTextLength))
+		b.WriteString(fmt.Sprintf("%s (>=%.0fs and >=%d chars):", s.key.Render("Normalized WPM"), session.MinValidDuration.Seconds(), session.MinValidTextLength))
 		b.WriteString("\n")
 		b.WriteString(fmt.Sprintf("  ├─ %s %s\n", s.key.Render("Average:"), s.val.Render(fmt.Sprintf("%.1f wpm", stats.NormalizedAvgWPM))))
 		b.WriteString(fmt.Sprintf("  ├─ %s %s\n", s.key.Render("Peak:"), s.val.Render(fmt.Sprintf("%.1f wpm", stats.NormalizedPeakWPM))))
@@ -609,7 +609,7 @@ func (m StatisticsModel) renderStatisticsSummaryWithStats(stats *Statistics) str
 		}
 		b.WriteString(fmt.Sprintf("  └─ %s %s\n", s.key.Render("Recent avg:"), s.val.Render(recent)))
 
-		if stats.RecentValidCountUsed >= minSessionsForVariance && stats.VariancePercent > 0 {
+		if stats.RecentValidCountUsed >= analytics.MinSessionsForVariance && stats.VariancePercent > 0 {
 			varStyle := s.good
 			if stats.VariancePercent > highVarianceThreshold {
 				varStyle = s.bad
@@ -662,7 +662,7 @@ func (m StatisticsModel) renderStatisticsSummaryWithStats(stats *Statistics) str
 	return b.String()
 }
 
-func (m StatisticsModel) renderPerformanceAnalysisWithStats(stats *Statistics) string {
+func (m StatisticsModel) renderPerformanceAnalysisWithStats(stats *analytics.Statistics) string {
 	s := m.styles
 	var b strings.Builder
 
@@ -801,6 +801,184 @@ func (m StatisticsModel) renderAchievements() string {
 	return b.String()
 }
 
+func (m StatisticsModel) renderBreakdownWithStats(stats *analytics.Statistics) string {
+	s := m.styles
+	var b strings.Builder
+
+	b.WriteString(s.section.Render("BREAKDOWN BY MODE, LANGUAGE AND LENGTH"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 79))
+	b.WriteString("\n")
+
+	if len(stats.Breakdown) == 0 {
+		b.WriteString(s.subtle.Render("No valid sessions in this view."))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	b.WriteString(s.subtle.Render(fmt.Sprintf("%-14s %-12s %-7s %8s %8s %8s %8s %8s",
+		"MODE", "LANGUAGE", "LENGTH", "SESSIONS", "AVG WPM", "BEST WPM", "ACCURACY", "TREND")))
+	b.WriteString("\n")
+
+	for _, row := range stats.Breakdown {
+		language := row.Language
+		if language == "" {
+			language = "-"
+		}
+		line := fmt.Sprintf("%-14s %-12s %-7s %8d %8.1f %8.1f %7.1f%% ",
+			truncateLabel(row.Mode, 14), truncateLabel(language, 12), row.Duration,
+			row.Sessions, row.AvgWPM, row.BestWPM, row.AvgAccuracy)
+		b.WriteString(s.val.Render(line))
+
+		switch {
+		case row.Trend > 0:
+			b.WriteString(s.good.Render(fmt.Sprintf("%+7.1f%%", row.Trend)))
+		case row.Trend < 0:
+			b.WriteString(s.bad.Render(fmt.Sprintf("%+7.1f%%", row.Trend)))
+		default:
+			b.WriteString(s.subtle.Render(fmt.Sprintf("%8s", "—")))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m StatisticsModel) renderPersonalBests() string {
+	s := m.styles
+	var b strings.Builder
+
+	b.WriteString(s.section.Render("PERSONAL BESTS"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 79))
+	b.WriteString("\n")
+
+	if len(m.bests) == 0 {
+		b.WriteString(s.subtle.Render("No personal bests yet. Finish a session of at least 15s to set one."))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	b.WriteString(s.subtle.Render(fmt.Sprintf("%-36s %8s %9s  %s", "CATEGORY", "WPM", "ACCURACY", "SET ON")))
+	b.WriteString("\n")
+	for _, best := range m.bests {
+		b.WriteString(s.val.Render(fmt.Sprintf("%-36s ", truncateLabel(best.Category, 36))))
+		b.WriteString(s.good.Render(fmt.Sprintf("%8.1f", best.WPM)))
+		b.WriteString(s.val.Render(fmt.Sprintf(" %8.1f%%  %s", best.Accuracy, best.Timestamp.Local().Format("2006-01-02"))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m StatisticsModel) renderTyposWithStats(stats *analytics.Statistics) string {
+	s := m.styles
+	var b strings.Builder
+
+	b.WriteString(s.section.Render("TYPO BREAKDOWN"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 79))
+	b.WriteString("\n")
+
+	typos := stats.Typos
+	if stats.TypoSessions == 0 {
+		b.WriteString(s.subtle.Render("No typo breakdown yet. Finished sessions record one from now on."))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+	sessions := fmt.Sprintf("%d sessions", stats.TypoSessions)
+	if stats.TypoSessions == 1 {
+		sessions = "1 session"
+	}
+	if typos.Total() == 0 {
+		b.WriteString(s.good.Render("No typos left in " + sessions + "."))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	const barMax = 30
+	kinds := []struct {
+		name  string
+		count int
+	}{
+		{"Substitutions", typos.Substitutions},
+		{"Insertions", typos.Insertions},
+		{"Omissions", typos.Omissions},
+		{"Transpositions", typos.Transpositions},
+	}
+	b.WriteString(s.subtle.Render("Typos left in the text across " + sessions))
+	b.WriteString("\n")
+	for _, k := range kinds {
+		share := float64(k.count) / float64(typos.Total())
+		b.WriteString(s.key.Render(fmt.Sprintf("%-16s", k.name)))
+		b.WriteString(s.val.Render(fmt.Sprintf("%5d %4.0f%% ", k.count, share*100)))
+		b.WriteString(s.accent.Render(strings.Repeat("█", int(math.Round(share*barMax)))))
+		b.WriteString("\n")
+	}
+	if len(typos.Confusions) > 0 {
+		b.WriteString(s.key.Render("Most confused:  "))
+		b.WriteString(s.bad.Render(session.FormatConfusions(typos.Confusions, 8)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m StatisticsModel) renderGoals() string {
+	s := m.styles
+	var b strings.Builder
+
+	b.WriteString(s.section.Render("GOALS"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 79))
+	b.WriteString("\n")
+
+	if !m.config.Goals.IsSet() {
+		b.WriteString(s.subtle.Render("No goals set. Set one with: gti goals set --minutes-per-day 20"))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	const barWidth = 30
+	progress := session.CalculateGoalProgress(m.config.Goals, m.records, time.Now())
+	for _, item := range progress.Items() {
+		filled := int(item.Fraction() * barWidth)
+		value := fmt.Sprintf("%.0f/%.0f %s", item.Current, item.Target, item.Unit)
+		if item.Unit == "wpm" {
+			value = fmt.Sprintf("%.1f/%.0f %s", item.Current, item.Target, item.Unit)
+		}
+
+		barStyle := s.accent
+		if item.Done {
+			barStyle = s.good
+		}
+		b.WriteString(s.key.Render(fmt.Sprintf("%-18s ", item.Name)))
+		b.WriteString(barStyle.Render(strings.Repeat("█", filled)))
+		b.WriteString(s.subtle.Render(strings.Repeat("░", barWidth-filled)))
+		b.WriteString(s.val.Render(" " + value))
+		if item.Done {
+			b.WriteString(s.good.Render(" ✓"))
+		}
+		if item.Note != "" {
+			b.WriteString(s.subtle.Render(" (" + item.Note + ")"))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+func truncateLabel(label string, width int) string {
+	if len(label) > width {
+		return label[:width-1] + "…"
+	}
+	return label
+}
+
 func (m StatisticsModel) renderRecentSessionsWithRecords(records []*session.SessionRecord) string {
 	s := m.styles
 	var b strings.Builder
@@ -859,7 +1037,7 @@ func (m StatisticsModel) renderRecentSessionsWithRecords(records []*session.Sess
 	return b.String()
 }
 
-func (m StatisticsModel) renderTrendChartWithStats(stats *Statistics) string {
+func (m StatisticsModel) renderTrendChartWithStats(stats *analytics.Statistics) string {
 	s := m.styles
 	var b strings.Builder
 
@@ -940,154 +1118,6 @@ func (m StatisticsModel) renderTrendChartWithStats(stats *Statistics) string {
 	return b.String()
 }
 
-func calculateStatistics(records []*session.SessionRecord) *Statistics {
-	stats := &Statistics{}
-	totalSessions := len(records)
-	if totalSessions == 0 {
-		return stats
-	}
-
-	calculateBasicStats(records, stats)
-
-	valid := filterValidSessions(records)
-	stats.ValidSessions = valid
-	stats.OutlierCount = totalSessions - len(valid)
-
-	if len(valid) == 0 {
-		return stats
-	}
-
-	calculateNormalizedStats(valid, stats)
-
-	calculateRecentPerformance(valid, stats)
-
-	calculateImprovementRate(valid, stats)
-
-	stats.CurrentStreak, stats.LongestStreak = session.CalculateStreaks(valid)
-
-	return stats
-}
-
-func calculateBasicStats(records []*session.SessionRecord, stats *Statistics) {
-	totalSessions := len(records)
-	var totalWPM, totalAccuracy float64
-	var totalMistakes int
-	var totalDurationMs int64
-	var totalBackspaces int
-	var totalCorrectedErrors, totalUncorrectedErrors int
-
-	for _, r := range records {
-		totalWPM += r.WPM
-		totalAccuracy += r.Accuracy
-		totalMistakes += r.Mistakes
-		totalDurationMs += r.DurationMs
-		totalBackspaces += r.BackspaceCount
-		totalCorrectedErrors += r.CorrectedErrors
-		totalUncorrectedErrors += r.UncorrectedErrors
-
-		if r.WPM > stats.RawPeakWPM {
-			stats.RawPeakWPM = r.WPM
-		}
-		if r.Accuracy > stats.RawBestAccuracy {
-			stats.RawBestAccuracy = r.Accuracy
-		}
-	}
-
-	stats.TotalSessions = totalSessions
-	stats.TotalTime = time.Duration(totalDurationMs) * time.Millisecond
-	stats.RawAvgWPM = totalWPM / float64(totalSessions)
-	stats.RawAvgAccuracy = totalAccuracy / float64(totalSessions)
-	stats.AvgMistakes = float64(totalMistakes) / float64(totalSessions)
-	stats.BackspaceRate = float64(totalBackspaces) / float64(totalSessions)
-	stats.AvgCorrectedErrors = float64(totalCorrectedErrors) / float64(totalSessions)
-	stats.AvgUncorrectedErrors = float64(totalUncorrectedErrors) / float64(totalSessions)
-}
-
-func filterValidSessions(records []*session.SessionRecord) []*session.SessionRecord {
-	valid := make([]*session.SessionRecord, 0, len(records))
-	for _, r := range records {
-		d := time.Duration(r.DurationMs) * time.Millisecond
-		if d >= minValidDuration && r.TextLength >= minValidTextLength {
-			valid = append(valid, r)
-		}
-	}
-	return valid
-}
-
-func calculateNormalizedStats(valid []*session.SessionRecord, stats *Statistics) {
-	var sumValid, sumNetWPM, sumAdjustedWPM float64
-	var maxValid, maxNetWPM, maxAdjustedWPM float64
-
-	for _, r := range valid {
-		sumValid += r.WPM
-		sumNetWPM += r.NetWPM
-		sumAdjustedWPM += r.AdjustedWPM
-
-		if r.WPM > maxValid {
-			maxValid = r.WPM
-		}
-		if r.NetWPM > maxNetWPM {
-			maxNetWPM = r.NetWPM
-		}
-		if r.AdjustedWPM > maxAdjustedWPM {
-			maxAdjustedWPM = r.AdjustedWPM
-		}
-	}
-
-	stats.NormalizedAvgWPM = sumValid / float64(len(valid))
-	stats.NormalizedPeakWPM = maxValid
-	stats.NetAvgWPM = sumNetWPM / float64(len(valid))
-	stats.NetPeakWPM = maxNetWPM
-	stats.AdjustedAvgWPM = sumAdjustedWPM / float64(len(valid))
-	stats.AdjustedPeakWPM = maxAdjustedWPM
-}
-
-func calculateRecentPerformance(valid []*session.SessionRecord, stats *Statistics) {
-	recentN := recentSessionsCount
-	if len(valid) < recentN {
-		recentN = len(valid)
-	}
-	stats.RecentValidCountUsed = recentN
-
-	var recentSum float64
-	for i := 0; i < recentN; i++ {
-		recentSum += valid[i].WPM
-	}
-	stats.RecentValidAvgWPM = recentSum / float64(recentN)
-
-	if recentN >= minSessionsForVariance && stats.RecentValidAvgWPM > 0 {
-		var variance float64
-		for i := 0; i < recentN; i++ {
-			diff := valid[i].WPM - stats.RecentValidAvgWPM
-			variance += diff * diff
-		}
-		variance /= float64(recentN)
-		stdDev := math.Sqrt(variance)
-		stats.ConsistencyScore = (stdDev / stats.RecentValidAvgWPM) * 100
-		stats.VariancePercent = stats.ConsistencyScore
-	}
-}
-
-func calculateImprovementRate(valid []*session.SessionRecord, stats *Statistics) {
-	if len(valid) >= minSessionsForImprovement {
-		half := len(valid) / 2
-
-		var newerSum, olderSum float64
-		for i := 0; i < half; i++ {
-			newerSum += valid[i].WPM
-			olderSum += valid[len(valid)-1-i].WPM
-		}
-
-		newerAvg := newerSum / float64(half)
-		olderAvg := olderSum / float64(half)
-		if olderAvg > 0 {
-			stats.ImprovementRate = ((newerAvg - olderAvg) / olderAvg) * 100
-		}
-	}
-}
-
-
-
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Round(time.Second).Seconds()))